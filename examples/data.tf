@@ -1,3 +1,12 @@
 data "rightbrain_model" "gpt-4o-mini" {
   name = "gpt-4o-mini"
-}
\ No newline at end of file
+}
+
+data "rightbrain_models" "openai-vision" {
+  model_provider  = "openai"
+  supports_vision = true
+}
+
+data "rightbrain_tasks" "enabled" {
+  enabled = true
+}
@@ -0,0 +1,34 @@
+ephemeral "rightbrain_task_access_token" "joke" {
+  task_id = rightbrain_task.tell-me-a-joke.id
+}
+
+resource "aws_secretsmanager_secret_version" "joke_token" {
+  secret_id                = aws_secretsmanager_secret.joke_token.id
+  secret_string_wo         = ephemeral.rightbrain_task_access_token.joke.access_token
+  secret_string_wo_version = 1
+}
+
+ephemeral "rightbrain_oauth_token" "api" {}
+
+resource "vault_kv_secret_v2" "rightbrain" {
+  mount = "secret"
+  name  = "rightbrain"
+  data_json_wo = jsonencode({
+    token = ephemeral.rightbrain_oauth_token.api.access_token
+  })
+  data_json_wo_version = 1
+}
+
+ephemeral "rightbrain_task_run" "joke" {
+  task_id = rightbrain_task.tell-me-a-joke.id
+  inputs = {
+    subject = "chickens"
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = jsondecode(self.output).sentiment == "positive"
+      error_message = "The joke about chickens is no longer positive."
+    }
+  }
+}
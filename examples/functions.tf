@@ -0,0 +1,31 @@
+locals {
+  joke_params = provider::rightbrain::prompt_params(rightbrain_task.tell-me-a-joke.user_prompt)
+}
+
+output "joke_prompt_preview" {
+  value = provider::rightbrain::render_prompt(rightbrain_task.tell-me-a-joke.user_prompt, {
+    subject = "chickens"
+  })
+}
+
+resource "rightbrain_task" "review-summary" {
+  name          = "Summarise a review"
+  llm_model_id  = data.rightbrain_model.gpt-4o-mini.id
+  system_prompt = "You summarise product reviews."
+  user_prompt   = "Summarise this review: {review}"
+  output_format = provider::rightbrain::output_format_from_jsonschema(file("${path.module}/schemas/review-summary.json"))
+}
+
+output "review_summary_schema" {
+  value = provider::rightbrain::output_format_to_jsonschema(rightbrain_task.review-summary.output_format)
+}
+
+check "review_summary_fixture" {
+  assert {
+    condition = length(provider::rightbrain::validate_output(
+      rightbrain_task.review-summary.output_format,
+      file("${path.module}/fixtures/review-summary.json"),
+    )) == 0
+    error_message = "The review summary fixture no longer matches the output format."
+  }
+}
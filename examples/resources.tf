@@ -9,4 +9,42 @@ resource "rightbrain_task" "tell-me-a-joke" {
   output_format = {
     "joke" : "str"
   }
-}
\ No newline at end of file
+}
+
+resource "rightbrain_task_forwarder" "webhook" {
+  name            = "Joke webhook"
+  destination_url = "https://example.com/hooks/jokes"
+  headers = {
+    "X-Team" : "platform"
+  }
+  auth_secret_wo         = var.webhook_secret
+  auth_secret_wo_version = 1
+}
+
+resource "rightbrain_task_evaluation" "joke" {
+  task_id = rightbrain_task.tell-me-a-joke.id
+
+  case {
+    name   = "chickens"
+    inputs = { subject = "chickens" }
+
+    expect {
+      path    = "joke"
+      matches = "(?i)chicken"
+    }
+  }
+
+  case {
+    name   = "sentiment"
+    inputs = { subject = "puppies" }
+
+    expect {
+      path   = "sentiment"
+      equals = jsonencode("positive")
+    }
+  }
+
+  lifecycle {
+    replace_triggered_by = [rightbrain_task.tell-me-a-joke.active_revision_id]
+  }
+}
@@ -0,0 +1,8 @@
+list "rightbrain_task" "jokes" {
+  provider = rightbrain
+
+  config {
+    name_prefix = "Joke"
+    enabled     = true
+  }
+}
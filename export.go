@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+
+	"terraform-provider-tasks/internal/export"
+	"terraform-provider-tasks/internal/provider"
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+)
+
+// runExport implements the `export` subcommand, which writes rightbrain_task
+// configuration and import blocks for the tasks in a project. Credentials are
+// read from RIGHTBRAIN_CLIENT_ID and RIGHTBRAIN_CLIENT_SECRET so that they
+// never appear in the process list.
+func runExport(ctx context.Context, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	apiHost := fs.String("api-host", getenv("RIGHTBRAIN_API_HOST", provider.DefaultAPIHost), "the hostname for the Rightbrain API server")
+	oauthHost := fs.String("oauth-host", getenv("RIGHTBRAIN_OAUTH_HOST", provider.DefaultOAuthHost), "the hostname for the Rightbrain OAuth server")
+	orgID := fs.String("org-id", os.Getenv("RIGHTBRAIN_ORG_ID"), "the org ID")
+	projectID := fs.String("project-id", os.Getenv("RIGHTBRAIN_PROJECT_ID"), "the project ID")
+	name := fs.String("name", "", "only export tasks whose name contains this value")
+	enabledOnly := fs.Bool("enabled-only", false, "only export enabled tasks")
+	out := fs.String("out", "", "the file to write the configuration to, defaults to stdout")
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	config := sdk.Config{
+		RightbrainAPIHost:      *apiHost,
+		RightbrainClientID:     os.Getenv("RIGHTBRAIN_CLIENT_ID"),
+		RightbrainClientSecret: os.Getenv("RIGHTBRAIN_CLIENT_SECRET"),
+		RightbrainOrgID:        *orgID,
+		RightbrainProjectID:    *projectID,
+	}
+	if config.RightbrainClientID == "" || config.RightbrainClientSecret == "" {
+		return errors.New("RIGHTBRAIN_CLIENT_ID and RIGHTBRAIN_CLIENT_SECRET must be set")
+	}
+	if config.RightbrainOrgID == "" || config.RightbrainProjectID == "" {
+		return errors.New("-org-id and -project-id, or RIGHTBRAIN_ORG_ID and RIGHTBRAIN_PROJECT_ID, must be set")
+	}
+
+	tokenStore, err := sdk.NewDefaultTokenStore(fmt.Sprintf("%s/oauth2/token", *oauthHost))
+	if err != nil {
+		return err
+	}
+	client := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, tokenStore, config)
+
+	in := sdk.NewListTasksRequest()
+	in.Name = *name
+	if *enabledOnly {
+		in.Enabled = enabledOnly
+	}
+
+	var tasks []entitites.Task
+	for task, err := range client.FetchAllTasks(ctx, in) {
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	slog.Info("exporting tasks", "project_id", config.RightbrainProjectID, "count", len(tasks))
+
+	if *out == "" {
+		return export.WriteTasks(stdout, tasks)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := export.WriteTasks(f, tasks); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func getenv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
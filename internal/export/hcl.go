@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package export writes Terraform configuration for tasks that already exist
+// in a Rightbrain project, together with the import blocks that bring them
+// under management.
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+)
+
+const (
+	TaskResourceType = "rightbrain_task"
+)
+
+// WriteTasks writes an import block and a rightbrain_task resource block for
+// each task, ordered by name. Tasks without an active revision cannot be
+// represented by the resource and are written as a comment instead.
+func WriteTasks(w io.Writer, tasks []entitites.Task) error {
+	tasks = slices.Clone(tasks)
+	slices.SortStableFunc(tasks, func(a, b entitites.Task) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	bw := bufio.NewWriter(w)
+	names := make(map[string]bool, len(tasks))
+
+	for i, task := range tasks {
+		if i > 0 {
+			fmt.Fprint(bw, "\n")
+		}
+		rev, err := task.GetActiveRevision()
+		if err != nil {
+			fmt.Fprintf(bw, "# Task %q (%s) was not exported: %s\n", task.Name, task.ID, err)
+			continue
+		}
+		name := ResourceName(task.Name, names)
+		writeTask(bw, name, task, rev)
+	}
+
+	return bw.Flush()
+}
+
+// ResourceName turns a task name into a unique Terraform resource name,
+// recording it in used.
+func ResourceName(taskName string, used map[string]bool) string {
+	var sb strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(taskName) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+			underscore = false
+		} else if !underscore && sb.Len() > 0 {
+			sb.WriteRune('_')
+			underscore = true
+		}
+	}
+
+	base := strings.TrimSuffix(sb.String(), "_")
+	if base == "" {
+		base = "task"
+	} else if unicode.IsDigit(rune(base[0])) {
+		base = "task_" + base
+	}
+
+	name := base
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	used[name] = true
+
+	return name
+}
+
+func writeTask(w io.Writer, name string, task entitites.Task, rev *entitites.Revision) {
+	address := TaskResourceType + "." + name
+
+	imp := newBlock(1)
+	imp.attr("to", address)
+	imp.attr("id", quote(task.ID))
+	fmt.Fprintf(w, "import {\n%s}\n\n", imp)
+
+	res := newBlock(1)
+	res.attr("name", quote(task.Name))
+	if task.Description != "" {
+		res.attr("description", quote(task.Description))
+	}
+	res.attr("enabled", fmt.Sprint(task.Enabled))
+	if task.Public {
+		res.attr("public", "true")
+	}
+	if task.ExposedToAgents {
+		res.attr("exposed_to_agents", "true")
+	}
+
+	res.blank()
+	res.attr("llm_model_id", quote(rev.LLMModelID))
+	res.attr("system_prompt", str(rev.SystemPrompt, 1))
+	res.attr("user_prompt", str(rev.UserPrompt, 1))
+	if rev.ImageRequired {
+		res.attr("image_required", "true")
+	}
+	if !rev.OptimiseImages {
+		res.attr("optimise_images", "false")
+	}
+	if rev.OutputModality != "" && rev.OutputModality != "json" {
+		res.attr("output_modality", quote(rev.OutputModality))
+	}
+	res.attr("output_format", stringMap(rev.OutputFormat.StringMap(), 1))
+	if rev.TaskForwarderID != "" {
+		res.attr("task_forwarder_id", quote(rev.TaskForwarderID))
+	}
+
+	if rev.HasInputProcessors() {
+		processors := newBlock(2)
+		for i, ip := range *rev.InputProcessors {
+			if i > 0 {
+				processors.blank()
+			}
+			processor := newBlock(3)
+			processor.attr("param_name", quote(ip.ParamName))
+			processor.attr("input_processor", quote(ip.InputProcessor))
+			if len(ip.Config) > 0 {
+				processor.attr("config", stringMap(ip.Config, 3))
+			}
+			processors.block("input_processor", processor)
+		}
+		res.blank()
+		res.block("input_processors", processors)
+	}
+
+	if rev.HasRAG() {
+		rag := newBlock(2)
+		rag.attr("collection_id", quote(rev.RAG.CollectionID))
+		rag.attr("rag_param", quote(rev.RAG.RAGParam))
+		res.blank()
+		res.block("rag", rag)
+	}
+
+	fmt.Fprintf(w, "resource %q %q {\n%s}\n", TaskResourceType, name, res)
+}
+
+// block accumulates the body of an HCL block, aligning the equals signs of
+// consecutive single line attributes as `terraform fmt` does.
+type block struct {
+	depth int
+	items []blockItem
+}
+
+type blockItem struct {
+	name  string
+	value string
+	body  *block
+}
+
+func newBlock(depth int) *block {
+	return &block{depth: depth}
+}
+
+func (b *block) attr(name, value string) {
+	b.items = append(b.items, blockItem{name: name, value: value})
+}
+
+func (b *block) block(name string, body *block) {
+	b.items = append(b.items, blockItem{name: name, body: body})
+}
+
+func (b *block) blank() {
+	if len(b.items) > 0 {
+		b.items = append(b.items, blockItem{})
+	}
+}
+
+func (b *block) String() string {
+	var sb strings.Builder
+	indent := strings.Repeat("  ", b.depth)
+
+	for i := 0; i < len(b.items); {
+		// find the run of single line attributes starting at i
+		j, width := i, 0
+		for ; j < len(b.items) && b.items[j].isSingleLineAttr(); j++ {
+			width = max(width, len(b.items[j].name))
+		}
+		for ; i < j; i++ {
+			fmt.Fprintf(&sb, "%s%-*s = %s\n", indent, width, b.items[i].name, b.items[i].value)
+		}
+		if i == len(b.items) {
+			break
+		}
+
+		item := b.items[i]
+		switch {
+		case item.body != nil:
+			fmt.Fprintf(&sb, "%s%s {\n%s%s}\n", indent, item.name, item.body, indent)
+		case item.name != "":
+			fmt.Fprintf(&sb, "%s%s = %s\n", indent, item.name, item.value)
+		default:
+			sb.WriteString("\n")
+		}
+		i++
+	}
+
+	return sb.String()
+}
+
+func (bi blockItem) isSingleLineAttr() bool {
+	return bi.name != "" && bi.body == nil && !strings.Contains(bi.value, "\n")
+}
+
+// stringMap renders m as a multi line object expression with sorted keys.
+func stringMap(m map[string]string, depth int) string {
+	if len(m) == 0 {
+		return "{}"
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	body := newBlock(depth + 1)
+	for _, k := range keys {
+		body.attr(quote(k), quote(m[k]))
+	}
+
+	return fmt.Sprintf("{\n%s%s}", body, strings.Repeat("  ", depth))
+}
+
+// str renders s as a heredoc when it spans several lines, and as a quoted
+// string otherwise. The rendered expression always evaluates to exactly s.
+func str(s string, depth int) string {
+	if !strings.Contains(s, "\n") || strings.HasSuffix(s, "\n\n") || strings.ContainsFunc(s, isUnsafeInHeredoc) {
+		return quote(s)
+	}
+
+	delimiter := "EOT"
+	for i := 2; slices.ContainsFunc(strings.Split(s, "\n"), func(line string) bool {
+		return strings.TrimSpace(line) == delimiter
+	}); i++ {
+		delimiter = fmt.Sprintf("EOT%d", i)
+	}
+
+	body := escapeTemplate(s)
+	if strings.HasSuffix(s, "\n") {
+		return fmt.Sprintf("<<%s\n%s%s", delimiter, body, delimiter)
+	}
+
+	// heredocs always end in a newline, which chomp removes again
+	return fmt.Sprintf("chomp(<<%s\n%s\n%s\n%s)", delimiter, body, delimiter, strings.Repeat("  ", depth))
+}
+
+func isUnsafeInHeredoc(r rune) bool {
+	return unicode.IsControl(r) && r != '\n' && r != '\t'
+}
+
+// quote renders s as a quoted HCL string.
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range escapeTemplate(s) {
+		switch r {
+		case '\\':
+			sb.WriteString(`\\`)
+		case '"':
+			sb.WriteString(`\"`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// escapeTemplate escapes the template sequences that HCL would otherwise
+// interpolate.
+func escapeTemplate(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package export_test
+
+import (
+	"strings"
+	"testing"
+
+	"terraform-provider-tasks/internal/export"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTasks(t *testing.T) {
+
+	t.Run("test that it writes import and resource blocks", func(t *testing.T) {
+		tasks := []entitites.Task{
+			{
+				ID:          "task-2",
+				Name:        "Tell me a Joke!",
+				Description: `Tells a "joke"`,
+				Enabled:     true,
+				Revisions: []entitites.Revision{
+					{
+						ID:             "rev-1",
+						Active:         true,
+						LLMModelID:     "model-1",
+						SystemPrompt:   "You can tell good jokes about anything",
+						UserPrompt:     "Tell me a joke about {subject}.\nKeep it under ${limit} words.",
+						OptimiseImages: true,
+						OutputFormat:   entitites.OutputFormat{"joke": "str", "rating": map[string]any{"type": "int"}},
+						InputProcessors: &[]entitites.InputProcessor{
+							{ParamName: "subject", InputProcessor: "url_fetcher", Config: map[string]string{"extract_text": "true"}},
+						},
+						RAG: &entitites.RAG{CollectionID: "collection-1", RAGParam: "context"},
+					},
+				},
+			},
+			{
+				ID:        "task-1",
+				Name:      "1st triage",
+				Enabled:   false,
+				Public:    true,
+				Revisions: []entitites.Revision{{ID: "rev-2", Active: true, LLMModelID: "model-2", SystemPrompt: "Triage\n", UserPrompt: "{ticket}", OutputFormat: entitites.OutputFormat{}}},
+			},
+			{
+				ID:   "task-3",
+				Name: "Draft",
+			},
+		}
+
+		var sb strings.Builder
+		assert.NoError(t, export.WriteTasks(&sb, tasks))
+		assert.Equal(t, `import {
+  to = rightbrain_task.task_1st_triage
+  id = "task-1"
+}
+
+resource "rightbrain_task" "task_1st_triage" {
+  name    = "1st triage"
+  enabled = false
+  public  = true
+
+  llm_model_id = "model-2"
+  system_prompt = <<EOT
+Triage
+EOT
+  user_prompt     = "{ticket}"
+  optimise_images = false
+  output_format   = {}
+}
+
+# Task "Draft" (task-3) was not exported: could not find active revision for task
+
+import {
+  to = rightbrain_task.tell_me_a_joke
+  id = "task-2"
+}
+
+resource "rightbrain_task" "tell_me_a_joke" {
+  name        = "Tell me a Joke!"
+  description = "Tells a \"joke\""
+  enabled     = true
+
+  llm_model_id  = "model-1"
+  system_prompt = "You can tell good jokes about anything"
+  user_prompt = chomp(<<EOT
+Tell me a joke about {subject}.
+Keep it under $${limit} words.
+EOT
+  )
+  output_format = {
+    "joke"   = "str"
+    "rating" = "{\"type\":\"int\"}"
+  }
+
+  input_processors {
+    input_processor {
+      param_name      = "subject"
+      input_processor = "url_fetcher"
+      config = {
+        "extract_text" = "true"
+      }
+    }
+  }
+
+  rag {
+    collection_id = "collection-1"
+    rag_param     = "context"
+  }
+}
+`, sb.String())
+	})
+
+	t.Run("test that resource names are valid and unique", func(t *testing.T) {
+		used := map[string]bool{}
+		assert.Equal(t, "joke", export.ResourceName("Joke", used))
+		assert.Equal(t, "joke_2", export.ResourceName("joke!", used))
+		assert.Equal(t, "task", export.ResourceName("???", used))
+		assert.Equal(t, "fintech_pre_triage", export.ResourceName("  Fintech Pre-Triage  ", used))
+		assert.Equal(t, "caf", export.ResourceName("Café", used))
+	})
+}
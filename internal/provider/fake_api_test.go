@@ -0,0 +1,501 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/benbjohnson/clock"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fakeOrgID     = "00000001-00000000-00000000-00000000"
+	fakeProjectID = "019010a2-8327-2607-11d7-41bb0a8936d4"
+	// fakeOtherProjectID is a second project in the same org.
+	fakeOtherProjectID = "01901111-0000-0000-0000-000000000000"
+)
+
+// fakeTasksAPI is an in-memory stand-in for the Rightbrain API that stores
+// tasks as they are created and updated, and records every create and update
+// request body and every task listing query.
+type fakeTasksAPI struct {
+	t          *testing.T
+	lock       sync.Mutex
+	server     *httptest.Server
+	seq        int
+	tasks      map[string]*entitites.Task
+	forwarders map[string]*entitites.TaskForwarder
+	models     []entitites.Model
+	bodies     []map[string]any
+	queries    []url.Values
+
+	// clock is used by the clients of the API and by the provider in
+	// newTestProviderServer.
+	clock clock.Clock
+	runs  []fakeTaskRun
+}
+
+// fakeTaskRun records the input and uploaded file names of a task run.
+type fakeTaskRun struct {
+	input map[string]string
+	files []string
+}
+
+func newFakeTasksAPI(t *testing.T) *fakeTasksAPI {
+	api := &fakeTasksAPI{
+		t:          t,
+		tasks:      make(map[string]*entitites.Task),
+		forwarders: make(map[string]*entitites.TaskForwarder),
+		clock:      clock.New(),
+	}
+	api.server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	t.Cleanup(api.server.Close)
+	return api
+}
+
+func (api *fakeTasksAPI) client() *sdk.TasksClient {
+	ts, err := sdk.NewTokenStore(sdk.NullLog{}, api.clock, http.DefaultClient, api.server.URL+"/oauth2/token")
+	assert.NoError(api.t, err)
+	return sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+		RightbrainAPIHost:   api.server.URL,
+		RightbrainOrgID:     fakeOrgID,
+		RightbrainProjectID: fakeProjectID,
+		Clock:               api.clock,
+	})
+}
+
+func (api *fakeTasksAPI) lastBody() map[string]any {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	if len(api.bodies) == 0 {
+		return nil
+	}
+	return api.bodies[len(api.bodies)-1]
+}
+
+func (api *fakeTasksAPI) lastQuery() url.Values {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+	if len(api.queries) == 0 {
+		return nil
+	}
+	return api.queries[len(api.queries)-1]
+}
+
+func (api *fakeTasksAPI) nextID() string {
+	api.seq++
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", api.seq)
+}
+
+func (api *fakeTasksAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	if r.URL.Path == "/oauth2/token" {
+		_, _ = fmt.Fprint(w, `{"access_token": "dummy-access-token", "expires_in": 3599}`)
+		return
+	}
+
+	prefix := fmt.Sprintf("/api/%s/org/%s/project/", sdk.DefaultAPIVersion, fakeOrgID)
+	projectID, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	resource := strings.Split(rest, "/")
+
+	switch {
+	case resource[0] == "model" && r.Method == http.MethodGet:
+		api.writeJSON(w, api.models)
+	case resource[0] == "task" && len(resource) == 1 && r.Method == http.MethodGet:
+		api.writeJSON(w, api.listTasks(r, projectID))
+	case resource[0] == "task" && len(resource) == 1 && r.Method == http.MethodPost:
+		in := api.decodeTaskRequest(r)
+		task := &entitites.Task{ID: api.nextID(), ProjectID: projectID}
+		api.applyTaskRequest(task, in)
+		task.Revisions[0].Active = true
+		api.tasks[task.ID] = task
+		api.writeJSON(w, task)
+	case resource[0] == "task" && len(resource) == 3 && resource[2] == "run" && r.Method == http.MethodPost:
+		task, ok := api.tasks[resource[1]]
+		if !ok || task.ProjectID != projectID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		api.writeJSON(w, api.runTask(r, task))
+	case resource[0] == "task" && len(resource) == 2:
+		task, ok := api.tasks[resource[1]]
+		if !ok || task.ProjectID != projectID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			api.writeJSON(w, task)
+		case http.MethodDelete:
+			delete(api.tasks, task.ID)
+		case http.MethodPost:
+			in := api.decodeTaskRequest(r)
+			if active, ok := in["active_revisions"].([]any); ok {
+				id := active[0].(map[string]any)["task_revision_id"] //nolint:forcetypeassert
+				for i := range task.Revisions {
+					task.Revisions[i].Active = task.Revisions[i].ID == id
+				}
+			} else {
+				api.applyTaskRequest(task, in)
+			}
+			api.writeJSON(w, task)
+		}
+	case resource[0] == "task_forwarder" && len(resource) == 1 && r.Method == http.MethodPost:
+		forwarder := &entitites.TaskForwarder{ID: api.nextID(), ProjectID: projectID}
+		api.applyTaskForwarderRequest(forwarder, api.decodeTaskRequest(r))
+		api.forwarders[forwarder.ID] = forwarder
+		api.writeJSON(w, forwarder)
+	case resource[0] == "task_forwarder" && len(resource) == 2:
+		forwarder, ok := api.forwarders[resource[1]]
+		if !ok || forwarder.ProjectID != projectID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			api.writeJSON(w, forwarder)
+		case http.MethodDelete:
+			delete(api.forwarders, forwarder.ID)
+		case http.MethodPost:
+			api.applyTaskForwarderRequest(forwarder, api.decodeTaskRequest(r))
+			api.writeJSON(w, forwarder)
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// listTasks returns a page of the tasks in a project ordered by ID, filtered
+// by name and enabled as the API does.
+func (api *fakeTasksAPI) listTasks(r *http.Request, projectID string) entitites.TaskList {
+	api.queries = append(api.queries, r.URL.Query())
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("page_limit"))
+	name := r.URL.Query().Get("name")
+	enabled := r.URL.Query().Get("enabled")
+
+	var tasks []entitites.Task
+	for _, task := range api.tasks {
+		if task.ProjectID != projectID {
+			continue
+		}
+		if name != "" && !strings.Contains(task.Name, name) {
+			continue
+		}
+		if enabled != "" && strconv.FormatBool(task.Enabled) != enabled {
+			continue
+		}
+		tasks = append(tasks, *task)
+	}
+	slices.SortFunc(tasks, func(a, b entitites.Task) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	start := min((page-1)*limit, len(tasks))
+	end := min(start+limit, len(tasks))
+
+	return entitites.TaskList{
+		Results: tasks[start:end],
+		Pagination: entitites.Pagination{
+			Page:      page,
+			PageLimit: limit,
+			Total:     len(tasks),
+			HasNext:   end < len(tasks),
+		},
+	}
+}
+
+// runTask records the run and responds with the input echoed back as the
+// output of the active revision.
+func (api *fakeTasksAPI) runTask(r *http.Request, task *entitites.Task) entitites.TaskRun {
+	run := fakeTaskRun{input: make(map[string]string)}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		assert.NoError(api.t, r.ParseMultipartForm(1<<20))
+		assert.NoError(api.t, json.Unmarshal([]byte(r.FormValue("task_input")), &run.input))
+		for _, file := range r.MultipartForm.File["task_input_files"] {
+			run.files = append(run.files, file.Filename)
+		}
+	} else {
+		var in sdk.RunTaskRequest
+		assert.NoError(api.t, json.NewDecoder(r.Body).Decode(&in))
+		run.input = in.Input
+	}
+	api.runs = append(api.runs, run)
+
+	rev, err := task.GetActiveRevision()
+	assert.NoError(api.t, err)
+
+	response, err := json.Marshal(map[string]any{"echo": run.input})
+	assert.NoError(api.t, err)
+
+	return entitites.TaskRun{
+		ID:             api.nextID(),
+		TaskID:         task.ID,
+		TaskRevisionID: rev.ID,
+		Response:       response,
+		InputTokens:    int64(len(run.input)),
+		OutputTokens:   1,
+	}
+}
+
+// addTask stores task as if it had been created through the API.
+func (api *fakeTasksAPI) addTask(name string) *entitites.Task {
+	api.lock.Lock()
+	defer api.lock.Unlock()
+
+	task := &entitites.Task{ID: api.nextID(), ProjectID: fakeProjectID}
+	api.applyTaskRequest(task, map[string]any{"name": name, "enabled": true, "user_prompt": "Tell me about {subject}"})
+	task.Revisions[0].Active = true
+	api.tasks[task.ID] = task
+	return task
+}
+
+func (api *fakeTasksAPI) decodeTaskRequest(r *http.Request) map[string]any {
+	in := make(map[string]any)
+	assert.NoError(api.t, json.NewDecoder(r.Body).Decode(&in))
+	return in
+}
+
+// applyTaskRequest updates task from a create or update request body and
+// prepends a new, inactive, revision as the API does.
+func (api *fakeTasksAPI) applyTaskRequest(task *entitites.Task, in map[string]any) {
+	api.bodies = append(api.bodies, in)
+
+	data, err := json.Marshal(in)
+	assert.NoError(api.t, err)
+
+	var req sdk.UpdateTaskRequest
+	assert.NoError(api.t, json.Unmarshal(data, &req))
+
+	task.Name = req.Name
+	task.Description = req.Description
+	task.Enabled = req.Enabled
+	task.Public = req.Public
+	task.ExposedToAgents = req.ExposedToAgents
+
+	now := api.clock.Now()
+	if task.Created.IsZero() {
+		task.Created = now
+	}
+	task.Modified = now
+
+	rev := entitites.Revision{
+		ID:              api.nextID(),
+		Created:         now,
+		Modified:        now,
+		ImageRequired:   req.ImageRequired,
+		InputProcessors: req.InputProcessors,
+		LLMModelID:      req.LLMModelID,
+		OptimiseImages:  req.OptimiseImages,
+		OutputFormat:    make(entitites.OutputFormat, len(req.OutputFormat)),
+		OutputModality:  req.OutputModality,
+		RAG:             req.RAG,
+		SystemPrompt:    req.SystemPrompt,
+		UserPrompt:      req.UserPrompt,
+	}
+	for k, v := range req.OutputFormat {
+		rev.OutputFormat[k] = v
+	}
+	if req.TaskForwarderID != nil {
+		rev.TaskForwarderID = *req.TaskForwarderID
+	}
+	rev.InputParams, _ = sdk.PromptParamNames(req.UserPrompt)
+
+	task.Revisions = append([]entitites.Revision{rev}, task.Revisions...)
+}
+
+// applyTaskForwarderRequest updates forwarder from a create or update
+// request body. Like the API it never returns the auth secret.
+func (api *fakeTasksAPI) applyTaskForwarderRequest(forwarder *entitites.TaskForwarder, in map[string]any) {
+	api.bodies = append(api.bodies, in)
+
+	data, err := json.Marshal(in)
+	assert.NoError(api.t, err)
+
+	var req sdk.UpdateTaskForwarderRequest
+	assert.NoError(api.t, json.Unmarshal(data, &req))
+
+	forwarder.Name = req.Name
+	forwarder.DestinationURL = req.DestinationURL
+	forwarder.Headers = req.Headers
+}
+
+func (api *fakeTasksAPI) writeJSON(w http.ResponseWriter, v any) {
+	assert.NoError(api.t, json.NewEncoder(w).Encode(v))
+}
+
+// newTestConfig returns values, a data source, resource or ephemeral resource
+// model, as a raw value of schema.
+func newTestConfig(t *testing.T, schema interface{ Type() attr.Type }, values any) tftypes.Value {
+	ctx := context.Background()
+
+	var obj types.Object
+	diags := tfsdk.ValueFrom(ctx, values, schema.Type(), &obj)
+	assert.False(t, diags.HasError(), diags)
+
+	raw, err := obj.ToTerraformValue(ctx)
+	assert.NoError(t, err)
+	return raw
+}
+
+// readTestDataSource reads d with config and returns the resulting state.
+func readTestDataSource[T any](t *testing.T, d datasource.DataSource, config T) (T, diag.Diagnostics) {
+	ctx := context.Background()
+
+	s := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, s)
+	raw := newTestConfig(t, s.Schema, config)
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: s.Schema, Raw: raw}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: s.Schema, Raw: raw}}, resp)
+
+	var data T
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+	return data, resp.Diagnostics
+}
+
+// newTestResourcePlan returns plan, a resource model, as the configuration
+// and plan of an operation on r. Unknown values in plan stand for computed
+// attributes left out of the configuration, and write-only attributes are
+// left out of the plan.
+func newTestResourcePlan[T any](t *testing.T, r resource.Resource, plan T) (tfsdk.Config, tfsdk.Plan) {
+	ctx := context.Background()
+
+	s := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, s)
+	raw := newTestConfig(t, s.Schema, plan)
+
+	config, err := tftypes.Transform(raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsKnown() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	assert.NoError(t, err)
+
+	planned, err := tftypes.Transform(raw, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if a, err := s.Schema.AttributeAtTerraformPath(ctx, p); err == nil && a.IsWriteOnly() {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	assert.NoError(t, err)
+
+	return tfsdk.Config{Schema: s.Schema, Raw: config}, tfsdk.Plan{Schema: s.Schema, Raw: planned}
+}
+
+// newTestResourceIdentity returns a null identity, as the framework passes to
+// every operation on a resource with identity support, or nil if r has none.
+func newTestResourceIdentity(t *testing.T, r resource.Resource) *tfsdk.ResourceIdentity {
+	ri, ok := r.(resource.ResourceWithIdentity)
+	if !ok {
+		return nil
+	}
+
+	ctx := context.Background()
+	resp := &resource.IdentitySchemaResponse{}
+	ri.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return &tfsdk.ResourceIdentity{Schema: resp.IdentitySchema, Raw: tftypes.NewValue(resp.IdentitySchema.Type().TerraformType(ctx), nil)}
+}
+
+// createTestResource creates r from plan and returns the resulting state.
+func createTestResource[T any](t *testing.T, r resource.Resource, plan T) (T, diag.Diagnostics) {
+	ctx := context.Background()
+
+	config, planned := newTestResourcePlan(t, r, plan)
+
+	resp := &resource.CreateResponse{
+		State:    tfsdk.State{Schema: planned.Schema, Raw: tftypes.NewValue(planned.Raw.Type(), nil)},
+		Identity: newTestResourceIdentity(t, r),
+	}
+	r.Create(ctx, resource.CreateRequest{Config: config, Plan: planned}, resp)
+
+	var data T
+	if !resp.State.Raw.IsNull() {
+		assert.False(t, resp.State.Get(ctx, &data).HasError())
+	}
+	return data, resp.Diagnostics
+}
+
+// updateTestResource updates r from prior to plan and returns the resulting
+// state.
+func updateTestResource[T any](t *testing.T, r resource.Resource, prior T, plan T) (T, diag.Diagnostics) {
+	ctx := context.Background()
+
+	config, planned := newTestResourcePlan(t, r, plan)
+
+	resp := &resource.UpdateResponse{State: tfsdk.State(planned), Identity: newTestResourceIdentity(t, r)}
+	r.Update(ctx, resource.UpdateRequest{
+		Config:   config,
+		Plan:     planned,
+		State:    tfsdk.State{Schema: planned.Schema, Raw: newTestConfig(t, planned.Schema, prior)},
+		Identity: newTestResourceIdentity(t, r),
+	}, resp)
+
+	var data T
+	assert.False(t, resp.State.Get(ctx, &data).HasError())
+	return data, resp.Diagnostics
+}
+
+// modifyTestResourcePlan lets r modify plan, proposed against prior or for a
+// new resource if prior is nil, and returns the resulting plan.
+func modifyTestResourcePlan[T any](t *testing.T, r resource.ResourceWithModifyPlan, prior *T, plan T) (T, diag.Diagnostics) {
+	ctx := context.Background()
+
+	config, planned := newTestResourcePlan(t, r, plan)
+	state := tfsdk.State{Schema: planned.Schema, Raw: tftypes.NewValue(planned.Raw.Type(), nil)}
+	if prior != nil {
+		state.Raw = newTestConfig(t, planned.Schema, *prior)
+	}
+
+	resp := &resource.ModifyPlanResponse{Plan: planned}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Config: config, Plan: planned, State: state}, resp)
+
+	var data T
+	assert.False(t, resp.Plan.Get(ctx, &data).HasError())
+	return data, resp.Diagnostics
+}
+
+// openTestEphemeralResource opens e with config and returns the result.
+func openTestEphemeralResource[T any](t *testing.T, e ephemeral.EphemeralResource, config T) (T, diag.Diagnostics) {
+	ctx := context.Background()
+
+	s := &ephemeral.SchemaResponse{}
+	e.Schema(ctx, ephemeral.SchemaRequest{}, s)
+	raw := newTestConfig(t, s.Schema, config)
+
+	resp := &ephemeral.OpenResponse{Result: tfsdk.EphemeralResultData{Schema: s.Schema, Raw: raw}}
+	e.Open(ctx, ephemeral.OpenRequest{Config: tfsdk.Config{Schema: s.Schema, Raw: raw}}, resp)
+
+	var data T
+	assert.False(t, resp.Result.Get(ctx, &data).HasError())
+	return data, resp.Diagnostics
+}
@@ -6,16 +6,23 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"terraform-provider-tasks/internal/sdk"
 	entitites "terraform-provider-tasks/internal/sdk/entities"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &LLMModelDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &LLMModelDataSource{}
 
 func NewLLMModelDataSource() datasource.DataSource {
 	return &LLMModelDataSource{}
@@ -35,6 +42,10 @@ type LLMModelDataSourceModel struct {
 	Name           types.String `tfsdk:"name"`
 	Provider       types.String `tfsdk:"model_provider"`
 	SupportsVision types.Bool   `tfsdk:"supports_vision"`
+
+	Deprecated         types.Bool   `tfsdk:"deprecated"`
+	SunsetDate         types.String `tfsdk:"sunset_date"`
+	ReplacementModelID types.String `tfsdk:"replacement_model_id"`
 }
 
 func (d *LLMModelDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -44,37 +55,75 @@ func (d *LLMModelDataSource) Metadata(ctx context.Context, req datasource.Metada
 func (d *LLMModelDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "LLM Model data source",
+		MarkdownDescription: "LLM Model data source. Looks up a single model by `id`, or by any combination of `name`, `alias`, `description`, `model_provider` and `supports_vision` that matches exactly one model.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				MarkdownDescription: "LLMModel identifier",
+				Optional:            true,
 				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("name"),
+						path.MatchRoot("alias"),
+						path.MatchRoot("description"),
+						path.MatchRoot("model_provider"),
+						path.MatchRoot("supports_vision"),
+					),
+				},
 			},
 			"alias": schema.StringAttribute{
 				Optional:    true,
-				Description: "",
+				Computed:    true,
+				Description: "The model alias, e.g. `gpt-4o-mini`.",
 			},
 			"description": schema.StringAttribute{
 				Optional:    true,
-				Description: "",
+				Computed:    true,
+				Description: "The model description. When set it must match the description of the model exactly.",
 			},
 			"name": schema.StringAttribute{
-				Required:    true,
-				Description: "",
+				Optional:    true,
+				Computed:    true,
+				Description: "The model name.",
 			},
 			"model_provider": schema.StringAttribute{
 				Optional:    true,
-				Description: "",
+				Computed:    true,
+				Description: "The model provider, e.g. `openai`.",
 			},
 			"supports_vision": schema.BoolAttribute{
 				Optional:    true,
-				Description: "",
+				Computed:    true,
+				Description: "When `true` the model accepts images.",
+			},
+			"deprecated": schema.BoolAttribute{
+				Computed:    true,
+				Description: "When `true` the model is being retired and should be replaced.",
+			},
+			"sunset_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "The date after which a deprecated model is removed from the catalog.",
+			},
+			"replacement_model_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the model suggested as a replacement for a deprecated model.",
 			},
 		},
 	}
 }
 
+func (d *LLMModelDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.AtLeastOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("alias"),
+			path.MatchRoot("model_provider"),
+		),
+	}
+}
+
 func (d *LLMModelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -111,26 +160,181 @@ func (d *LLMModelDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	var found *entitites.Model
+	candidates := data.filter(models)
 
-	for _, model := range models {
-		if model.Name == data.Name.ValueString() {
-			found = &model
-		}
+	if len(candidates) == 0 {
+		resp.Diagnostics.AddError(fmt.Sprintf("cannot find model matching %s", data.describeQuery()), data.suggest(models))
+		return
 	}
 
-	if found == nil {
-		resp.Diagnostics.AddError(fmt.Sprintf("cannot find model %s", data.Name), "")
+	if len(candidates) > 1 {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("more than one model matches %s", data.describeQuery()),
+			fmt.Sprintf("Add more attributes to narrow the lookup to one of:\n%s", formatModelList(candidates)),
+		)
 		return
 	}
 
+	found := candidates[0]
+
 	data.ID = types.StringValue(found.ID)
 	data.Alias = types.StringValue(found.Alias)
 	data.Description = types.StringValue(found.Description)
 	data.Name = types.StringValue(found.Name)
 	data.Provider = types.StringValue(found.Provider)
 	data.SupportsVision = types.BoolValue(found.SupportsVision)
+	data.Deprecated = types.BoolPointerValue(found.Deprecated)
+	data.SunsetDate = types.StringPointerValue(found.SunsetDate)
+	data.ReplacementModelID = types.StringPointerValue(found.ReplacementModelID)
+
+	if found.IsDeprecated() {
+		resp.Diagnostics.AddWarning("LLM model is deprecated", describeModelDeprecation(found, models))
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// filter returns the models matching every attribute set in the configuration.
+func (m *LLMModelDataSourceModel) filter(models []entitites.Model) []entitites.Model {
+	var matches []entitites.Model
+	for _, model := range models {
+		if !m.ID.IsNull() && model.ID != m.ID.ValueString() {
+			continue
+		}
+		if !m.Name.IsNull() && model.Name != m.Name.ValueString() {
+			continue
+		}
+		if !m.Alias.IsNull() && model.Alias != m.Alias.ValueString() {
+			continue
+		}
+		if !m.Description.IsNull() && model.Description != m.Description.ValueString() {
+			continue
+		}
+		if !m.Provider.IsNull() && model.Provider != m.Provider.ValueString() {
+			continue
+		}
+		if !m.SupportsVision.IsNull() && model.SupportsVision != m.SupportsVision.ValueBool() {
+			continue
+		}
+		matches = append(matches, model)
+	}
+	return matches
+}
+
+func (m *LLMModelDataSourceModel) describeQuery() string {
+	var parts []string
+	if !m.ID.IsNull() {
+		parts = append(parts, fmt.Sprintf("id = %q", m.ID.ValueString()))
+	}
+	if !m.Name.IsNull() {
+		parts = append(parts, fmt.Sprintf("name = %q", m.Name.ValueString()))
+	}
+	if !m.Alias.IsNull() {
+		parts = append(parts, fmt.Sprintf("alias = %q", m.Alias.ValueString()))
+	}
+	if !m.Description.IsNull() {
+		parts = append(parts, fmt.Sprintf("description = %q", m.Description.ValueString()))
+	}
+	if !m.Provider.IsNull() {
+		parts = append(parts, fmt.Sprintf("model_provider = %q", m.Provider.ValueString()))
+	}
+	if !m.SupportsVision.IsNull() {
+		parts = append(parts, fmt.Sprintf("supports_vision = %t", m.SupportsVision.ValueBool()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// suggest lists the models whose id, name, alias or provider are closest to
+// the configured values.
+func (m *LLMModelDataSourceModel) suggest(models []entitites.Model) string {
+	type suggestion struct {
+		model    entitites.Model
+		distance int
+	}
+	var suggestions []suggestion
+
+	for _, model := range models {
+		best := -1
+		for _, v := range []types.String{m.ID, m.Name, m.Alias} {
+			if v.IsNull() {
+				continue
+			}
+			for _, candidate := range []string{model.ID, model.Name, model.Alias} {
+				best = closest(best, v.ValueString(), candidate)
+			}
+		}
+		if !m.Provider.IsNull() {
+			best = closest(best, m.Provider.ValueString(), model.Provider)
+		}
+		if best >= 0 {
+			suggestions = append(suggestions, suggestion{model: model, distance: best})
+		}
+	}
+
+	if len(suggestions) == 0 {
+		return "No similar models were found, use the rightbrain_models data source to list the catalog."
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b suggestion) int {
+		return a.distance - b.distance
+	})
+
+	similar := make([]entitites.Model, 0, 5)
+	for i := 0; i < len(suggestions) && i < 5; i++ {
+		similar = append(similar, suggestions[i].model)
+	}
+
+	return fmt.Sprintf("Did you mean one of:\n%s", formatModelList(similar))
+}
+
+// closest returns the edit distance between query and candidate when they are
+// similar enough to suggest and closer than best, otherwise best. Substring
+// matches, such as "gpt-4o" for "gpt-4o-mini", count as exact.
+func closest(best int, query string, candidate string) int {
+	query, candidate = strings.ToLower(query), strings.ToLower(candidate)
+	if candidate == "" {
+		return best
+	}
+	distance := levenshtein(query, candidate)
+	if strings.Contains(candidate, query) || strings.Contains(query, candidate) {
+		distance = 0
+	}
+	if distance > max(2, len(query)/3) {
+		return best
+	}
+	if best == -1 || distance < best {
+		return distance
+	}
+	return best
+}
+
+func formatModelList(models []entitites.Model) string {
+	lines := make([]string, len(models))
+	for i, model := range models {
+		lines[i] = fmt.Sprintf("  - %s (alias %q, provider %q, id %q)", model.Name, model.Alias, model.Provider, model.ID)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMModelDataSource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	api.models = []entitites.Model{
+		{ID: "1", Name: "gpt-4o", Alias: "gpt-4o", Provider: "openai", SupportsVision: true},
+		{ID: "2", Name: "gpt-4o-mini", Alias: "gpt-4o-mini", Provider: "openai", Description: "Small and fast", SupportsVision: false},
+		{ID: "3", Name: "claude-3-5-sonnet", Alias: "sonnet", Provider: "anthropic", SupportsVision: true},
+	}
+
+	t.Run("test that it finds a model by alias", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &LLMModelDataSource{client: api.client()}, LLMModelDataSourceModel{Alias: types.StringValue("sonnet")})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue("3"), data.ID)
+		assert.Equal(t, types.StringValue("claude-3-5-sonnet"), data.Name)
+		assert.Equal(t, types.BoolNull(), data.Deprecated)
+		assert.Equal(t, types.StringNull(), data.SunsetDate)
+		assert.Equal(t, types.StringNull(), data.ReplacementModelID)
+	})
+
+	t.Run("test that it finds a model by provider and vision support", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &LLMModelDataSource{client: api.client()}, LLMModelDataSourceModel{
+			Provider:       types.StringValue("openai"),
+			SupportsVision: types.BoolValue(true),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue("1"), data.ID)
+	})
+
+	t.Run("test that it filters by description", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &LLMModelDataSource{client: api.client()}, LLMModelDataSourceModel{
+			Provider:    types.StringValue("openai"),
+			Description: types.StringValue("Small and fast"),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue("2"), data.ID)
+	})
+
+	t.Run("test that it lists candidates when more than one model matches", func(t *testing.T) {
+		_, diags := readTestDataSource(t, &LLMModelDataSource{client: api.client()}, LLMModelDataSourceModel{Provider: types.StringValue("openai")})
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, `more than one model matches model_provider = "openai"`, diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), `gpt-4o (alias "gpt-4o", provider "openai", id "1")`)
+		assert.Contains(t, diags[0].Detail(), `gpt-4o-mini (alias "gpt-4o-mini", provider "openai", id "2")`)
+	})
+
+	t.Run("test that it suggests similar models when none match", func(t *testing.T) {
+		_, diags := readTestDataSource(t, &LLMModelDataSource{client: api.client()}, LLMModelDataSourceModel{Name: types.StringValue("gpt4o-mini")})
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, `cannot find model matching name = "gpt4o-mini"`, diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "Did you mean one of:\n  - gpt-4o-mini")
+		assert.NotContains(t, diags[0].Detail(), "claude")
+	})
+}
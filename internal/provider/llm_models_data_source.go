@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &LLMModelsDataSource{}
+
+func NewLLMModelsDataSource() datasource.DataSource {
+	return &LLMModelsDataSource{}
+}
+
+// LLMModelsDataSource defines the data source implementation.
+type LLMModelsDataSource struct {
+	client *sdk.TasksClient
+}
+
+// LLMModelsDataSourceModel describes the data source data model.
+type LLMModelsDataSourceModel struct {
+	Provider       types.String `tfsdk:"model_provider"`
+	Alias          types.String `tfsdk:"alias"`
+	SupportsVision types.Bool   `tfsdk:"supports_vision"`
+	NameRegex      types.String `tfsdk:"name_regex"`
+
+	Models []LLMModelModel `tfsdk:"models"`
+}
+
+// LLMModelModel describes a single model in the catalog.
+type LLMModelModel struct {
+	ID             types.String `tfsdk:"id"`
+	Alias          types.String `tfsdk:"alias"`
+	Description    types.String `tfsdk:"description"`
+	Name           types.String `tfsdk:"name"`
+	Provider       types.String `tfsdk:"model_provider"`
+	SupportsVision types.Bool   `tfsdk:"supports_vision"`
+
+	Deprecated         types.Bool   `tfsdk:"deprecated"`
+	SunsetDate         types.String `tfsdk:"sunset_date"`
+	ReplacementModelID types.String `tfsdk:"replacement_model_id"`
+}
+
+func NewLLMModelModel(model entitites.Model) LLMModelModel {
+	return LLMModelModel{
+		ID:             types.StringValue(model.ID),
+		Alias:          types.StringValue(model.Alias),
+		Description:    types.StringValue(model.Description),
+		Name:           types.StringValue(model.Name),
+		Provider:       types.StringValue(model.Provider),
+		SupportsVision: types.BoolValue(model.SupportsVision),
+
+		Deprecated:         types.BoolPointerValue(model.Deprecated),
+		SunsetDate:         types.StringPointerValue(model.SunsetDate),
+		ReplacementModelID: types.StringPointerValue(model.ReplacementModelID),
+	}
+}
+
+func (d *LLMModelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_models"
+}
+
+func (d *LLMModelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "LLM Models data source. Lists the model catalog, optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"model_provider": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return models from this provider, e.g. `openai`.",
+			},
+			"alias": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return models with this alias.",
+			},
+			"supports_vision": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return models whose vision support matches this value.",
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return models whose name matches this regular expression.",
+			},
+			"models": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The models matching every filter, in catalog order.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "LLMModel identifier",
+						},
+						"alias": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"model_provider": schema.StringAttribute{
+							Computed: true,
+						},
+						"supports_vision": schema.BoolAttribute{
+							Computed: true,
+						},
+						"deprecated": schema.BoolAttribute{
+							Computed: true,
+						},
+						"sunset_date": schema.StringAttribute{
+							Computed: true,
+						},
+						"replacement_model_id": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LLMModelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *LLMModelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LLMModelsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid regular expression", err.Error())
+			return
+		}
+	}
+
+	models, err := d.client.GetAvailableLLMModels(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	data.Models = []LLMModelModel{}
+
+	for _, model := range models {
+		if !data.Provider.IsNull() && model.Provider != data.Provider.ValueString() {
+			continue
+		}
+		if !data.Alias.IsNull() && model.Alias != data.Alias.ValueString() {
+			continue
+		}
+		if !data.SupportsVision.IsNull() && model.SupportsVision != data.SupportsVision.ValueBool() {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(model.Name) {
+			continue
+		}
+		data.Models = append(data.Models, NewLLMModelModel(model))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLMModelsDataSource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	api.models = []entitites.Model{
+		{ID: "1", Name: "gpt-4o", Alias: "gpt-4o", Provider: "openai", SupportsVision: true},
+		{ID: "2", Name: "gpt-4o-mini", Alias: "gpt-4o-mini", Provider: "openai", SupportsVision: false},
+		{ID: "3", Name: "claude-3-5-sonnet", Alias: "sonnet", Provider: "anthropic", SupportsVision: true},
+	}
+
+	t.Run("test that it returns the whole catalog without filters", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &LLMModelsDataSource{client: api.client()}, LLMModelsDataSourceModel{})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, data.Models, 3)
+	})
+
+	t.Run("test that filters are combined", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &LLMModelsDataSource{client: api.client()}, LLMModelsDataSourceModel{
+			Provider:       types.StringValue("openai"),
+			SupportsVision: types.BoolValue(true),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, data.Models, 1)
+		assert.Equal(t, types.StringValue("1"), data.Models[0].ID)
+	})
+
+	t.Run("test that it filters by name regex", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &LLMModelsDataSource{client: api.client()}, LLMModelsDataSourceModel{
+			NameRegex: types.StringValue("mini$"),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, data.Models, 1)
+		assert.Equal(t, types.StringValue("gpt-4o-mini"), data.Models[0].Name)
+	})
+}
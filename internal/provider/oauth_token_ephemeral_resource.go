@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const oauthTokenPrivateKey = "token"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &OAuthTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &OAuthTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithRenew = &OAuthTokenEphemeralResource{}
+
+func NewOAuthTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &OAuthTokenEphemeralResource{}
+}
+
+// OAuthTokenEphemeralResource defines the ephemeral resource implementation.
+type OAuthTokenEphemeralResource struct {
+	client *sdk.TasksClient
+}
+
+// OAuthTokenEphemeralResourceModel describes the ephemeral resource data
+// model.
+type OAuthTokenEphemeralResourceModel struct {
+	AccessToken types.String `tfsdk:"access_token"`
+	TokenType   types.String `tfsdk:"token_type"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// oauthTokenPrivateData is kept between Open and Renew so that the token can
+// be checked against its lifetime.
+type oauthTokenPrivateData struct {
+	RefreshAt time.Time `json:"refresh_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (e *OAuthTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth_token"
+}
+
+func (e *OAuthTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A Rightbrain API bearer token minted with the provider credentials. The token is never stored in the plan or state, and remains valid until it expires.",
+
+		Attributes: map[string]schema.Attribute{
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The access token.",
+			},
+			"token_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "The type of the token, always `Bearer`.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the token expires.",
+			},
+		},
+	}
+}
+
+func (e *OAuthTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *OAuthTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	token, err := e.client.MintAccessToken(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	data := OAuthTokenEphemeralResourceModel{
+		AccessToken: types.StringValue(token.Value),
+		TokenType:   types.StringValue("Bearer"),
+		ExpiresAt:   timeToStringValue(token.ExpiresAt),
+	}
+
+	private, err := json.Marshal(oauthTokenPrivateData{RefreshAt: token.RefreshAt, ExpiresAt: token.ExpiresAt})
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, oauthTokenPrivateKey, private)...)
+
+	// Terraform cannot swap the token for a new one mid run, so the renewal
+	// only checks that it is still usable shortly before it expires.
+	resp.RenewAt = token.RefreshAt
+}
+
+func (e *OAuthTokenEphemeralResource) Renew(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse) {
+	private, diags := req.Private.GetKey(ctx, oauthTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var token oauthTokenPrivateData
+	if err := json.Unmarshal(private, &token); err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	now := e.client.Now()
+	if !now.Before(token.ExpiresAt) {
+		resp.Diagnostics.AddError(
+			"OAuth token expired",
+			fmt.Sprintf("The OAuth token expired at %s and cannot be replaced during a run. Anything still using it will be rejected by the API.", token.ExpiresAt.UTC().Format(time.RFC3339)),
+		)
+		return
+	}
+
+	// Terraform may renew early, before the token is due to be refreshed
+	if now.Before(token.RefreshAt) {
+		resp.RenewAt = token.RefreshAt
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"OAuth token about to expire",
+		fmt.Sprintf("The OAuth token expires at %s and cannot be replaced during a run. Anything using it after then will be rejected by the API.", token.ExpiresAt.UTC().Format(time.RFC3339)),
+	)
+	resp.RenewAt = token.ExpiresAt
+}
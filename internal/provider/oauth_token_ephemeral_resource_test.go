@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOAuthTokenEphemeralResource(t *testing.T) {
+	t.Run("test that it mints a token", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		mock := clock.NewMock()
+		api.clock = mock
+		server := newTestProviderServer(t, api)
+
+		opened := openTestOAuthToken(t, server)
+		assert.WithinDuration(t, mock.Now().Add(3239*time.Second), opened.RenewAt, 0)
+
+		var result map[string]tftypes.Value
+		assert.NoError(t, decodeTestOAuthToken(t, opened.Result).As(&result))
+		var accessToken, tokenType string
+		assert.NoError(t, result["access_token"].As(&accessToken))
+		assert.NoError(t, result["token_type"].As(&tokenType))
+		assert.Equal(t, "dummy-access-token", accessToken)
+		assert.Equal(t, "Bearer", tokenType)
+	})
+
+	t.Run("test that renewing warns only once the token is due to be refreshed", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		mock := clock.NewMock()
+		api.clock = mock
+		server := newTestProviderServer(t, api)
+
+		opened := openTestOAuthToken(t, server)
+		refreshAt := opened.RenewAt
+
+		mock.Add(time.Minute)
+		renewed := renewTestOAuthToken(t, server, opened)
+		assert.Empty(t, renewed.Diagnostics)
+		assert.WithinDuration(t, refreshAt, renewed.RenewAt, 0)
+
+		mock.Set(refreshAt)
+		renewed = renewTestOAuthToken(t, server, opened)
+		assert.Len(t, renewed.Diagnostics, 1)
+		assert.Equal(t, tfprotov6.DiagnosticSeverityWarning, renewed.Diagnostics[0].Severity)
+		assert.Equal(t, "OAuth token about to expire", renewed.Diagnostics[0].Summary)
+		assert.WithinDuration(t, refreshAt.Add(360*time.Second), renewed.RenewAt, 0)
+	})
+
+	t.Run("test that renewing an expired token is an error", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		mock := clock.NewMock()
+		api.clock = mock
+		server := newTestProviderServer(t, api)
+
+		opened := openTestOAuthToken(t, server)
+
+		mock.Add(3599 * time.Second)
+		renewed := renewTestOAuthToken(t, server, opened)
+		assert.Len(t, renewed.Diagnostics, 1)
+		assert.Equal(t, tfprotov6.DiagnosticSeverityError, renewed.Diagnostics[0].Severity)
+		assert.Equal(t, "OAuth token expired", renewed.Diagnostics[0].Summary)
+	})
+}
+
+// newTestProviderServer returns a protocol server for the provider, configured
+// to talk to api.
+func newTestProviderServer(t *testing.T, api *fakeTasksAPI) tfprotov6.ProviderServer {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(&RightbrainProvider{version: "test", clock: api.clock})()
+	assert.NoError(t, err)
+
+	s := &provider.SchemaResponse{}
+	(&RightbrainProvider{}).Schema(ctx, provider.SchemaRequest{}, s)
+	configType := s.Schema.Type().TerraformType(ctx)
+
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+		"api_host":        tftypes.NewValue(tftypes.String, api.server.URL),
+		"oauth_host":      tftypes.NewValue(tftypes.String, api.server.URL),
+		"client_id":       tftypes.NewValue(tftypes.String, "client-id"),
+		"client_secret":   tftypes.NewValue(tftypes.String, "client-secret"),
+		"org_id":          tftypes.NewValue(tftypes.String, fakeOrgID),
+		"project_id":      tftypes.NewValue(tftypes.String, fakeProjectID),
+		"model_cache_ttl": tftypes.NewValue(tftypes.String, nil),
+	}))
+	assert.NoError(t, err)
+
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &config})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+
+	return server
+}
+
+func openTestOAuthToken(t *testing.T, server tfprotov6.ProviderServer) *tfprotov6.OpenEphemeralResourceResponse {
+	ctx := context.Background()
+
+	s := getTestOAuthTokenSchema(t)
+	config, err := tfprotov6.NewDynamicValue(s.Type().TerraformType(ctx), newTestConfig(t, s, OAuthTokenEphemeralResourceModel{}))
+	assert.NoError(t, err)
+
+	resp, err := server.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{TypeName: "rightbrain_oauth_token", Config: &config})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+	return resp
+}
+
+func renewTestOAuthToken(t *testing.T, server tfprotov6.ProviderServer, opened *tfprotov6.OpenEphemeralResourceResponse) *tfprotov6.RenewEphemeralResourceResponse {
+	resp, err := server.RenewEphemeralResource(context.Background(), &tfprotov6.RenewEphemeralResourceRequest{TypeName: "rightbrain_oauth_token", Private: opened.Private})
+	assert.NoError(t, err)
+	return resp
+}
+
+func decodeTestOAuthToken(t *testing.T, value *tfprotov6.DynamicValue) tftypes.Value {
+	decoded, err := value.Unmarshal(getTestOAuthTokenSchema(t).Type().TerraformType(context.Background()))
+	assert.NoError(t, err)
+	return decoded
+}
+
+func getTestOAuthTokenSchema(t *testing.T) schema.Schema {
+	s := &ephemeral.SchemaResponse{}
+	NewOAuthTokenEphemeralResource().Schema(context.Background(), ephemeral.SchemaRequest{}, s)
+	assert.False(t, s.Diagnostics.HasError(), s.Diagnostics)
+	return s.Schema
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &OutputFormatFromJSONSchemaFunction{}
+
+func NewOutputFormatFromJSONSchemaFunction() function.Function {
+	return &OutputFormatFromJSONSchemaFunction{}
+}
+
+// OutputFormatFromJSONSchemaFunction defines the function implementation.
+type OutputFormatFromJSONSchemaFunction struct{}
+
+func (f *OutputFormatFromJSONSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "output_format_from_jsonschema"
+}
+
+func (f *OutputFormatFromJSONSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Converts a JSON Schema into an output format",
+		MarkdownDescription: "Converts a JSON Schema that describes an object into the `output_format` of a `rightbrain_task`. Each property becomes a field: `string`, `integer`, `number`, `boolean`, `array` and `object` become `str`, `int`, `float`, `bool`, `list` and `object`. Descriptions, string enums, array items and nested properties are kept. Every field is present in the output of a run, so each object must list all of its properties in `required`. Constructs that an output format cannot express, such as `$ref`, `oneOf` or lists of lists, are an error.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "schema_json",
+				Description: "The JSON encoding of the schema.",
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *OutputFormatFromJSONSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var schema string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &schema))
+
+	if resp.Error != nil {
+		return
+	}
+
+	fields, err := sdk.OutputFormatFromJSONSchema([]byte(schema))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	format, err := sdk.FormatOutputFormat(fields)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, format))
+}
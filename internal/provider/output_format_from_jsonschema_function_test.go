@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormatFromJSONSchemaFunction(t *testing.T) {
+
+	t.Run("test that it returns the output format", func(t *testing.T) {
+		resp := runTestFunction(t, NewOutputFormatFromJSONSchemaFunction(), types.MapUnknown(types.StringType),
+			types.StringValue(`{
+				"type": "object",
+				"properties": {
+					"joke": {"type": "string"},
+					"rating": {"type": "integer", "description": "From 1 to 10"}
+				},
+				"required": ["joke", "rating"]
+			}`))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+			"joke":   types.StringValue("str"),
+			"rating": types.StringValue(`{"type":"int","description":"From 1 to 10"}`),
+		}), resp.Result.Value())
+	})
+
+	t.Run("test that the output format reaches the api as objects", func(t *testing.T) {
+		resp := runTestFunction(t, NewOutputFormatFromJSONSchemaFunction(), types.MapUnknown(types.StringType),
+			types.StringValue(`{
+				"type": "object",
+				"properties": {
+					"joke": {"type": "string"},
+					"tags": {"type": "array", "items": {"type": "string"}, "description": "Topics of the joke"}
+				},
+				"required": ["joke", "tags"]
+			}`))
+		assert.Nil(t, resp.Error)
+
+		var format map[string]types.String
+		assert.False(t, resp.Result.Value().(types.Map).ElementsAs(context.Background(), &format, false).HasError())
+
+		api := newFakeTasksAPI(t)
+		plan := newTestTaskResourceModel()
+		plan.OutputFormat = format
+		state, diags := createTestResource(t, newTestTaskResource(t, api), plan)
+		assert.False(t, diags.HasError(), diags)
+
+		assert.Equal(t, map[string]any{
+			"joke": "str",
+			"tags": map[string]any{"type": "list", "item_type": "str", "description": "Topics of the joke"},
+		}, api.lastBody()["output_format"])
+		assert.Equal(t, plan.OutputFormat, state.OutputFormat)
+	})
+
+	t.Run("test that unsupported constructs are an argument error", func(t *testing.T) {
+		resp := runTestFunction(t, NewOutputFormatFromJSONSchemaFunction(), types.MapUnknown(types.StringType),
+			types.StringValue(`{"type": "object", "properties": {"joke": {"anyOf": [{"type": "string"}]}}}`))
+		assert.Equal(t, function.NewArgumentFuncError(0, `joke: unsupported keyword "anyOf"`), resp.Error)
+	})
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &OutputFormatToJSONSchemaFunction{}
+
+func NewOutputFormatToJSONSchemaFunction() function.Function {
+	return &OutputFormatToJSONSchemaFunction{}
+}
+
+// OutputFormatToJSONSchemaFunction defines the function implementation.
+type OutputFormatToJSONSchemaFunction struct{}
+
+func (f *OutputFormatToJSONSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "output_format_to_jsonschema"
+}
+
+func (f *OutputFormatToJSONSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Converts an output format into a JSON Schema",
+		MarkdownDescription: "Converts the `output_format` of a `rightbrain_task` into the JSON encoding of a JSON Schema that describes the outputs of the Task. Every field is required and no other properties are allowed. This is the inverse of `output_format_from_jsonschema`.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:        "output_format",
+				ElementType: types.StringType,
+				Description: "The output format to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *OutputFormatToJSONSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var format map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &format))
+
+	if resp.Error != nil {
+		return
+	}
+
+	fields, err := sdk.ParseOutputFormat(format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	schema, err := json.Marshal(sdk.OutputFormatToJSONSchema(fields))
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, string(schema)))
+}
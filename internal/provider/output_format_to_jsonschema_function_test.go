@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormatToJSONSchemaFunction(t *testing.T) {
+
+	t.Run("test that it returns the json schema", func(t *testing.T) {
+		resp := runTestFunction(t, NewOutputFormatToJSONSchemaFunction(), types.StringUnknown(),
+			types.MapValueMust(types.StringType, map[string]attr.Value{
+				"joke":   types.StringValue("str"),
+				"rating": types.StringValue(`{"type": "int", "description": "From 1 to 10"}`),
+			}))
+		assert.Nil(t, resp.Error)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"joke": {"type": "string"},
+				"rating": {"type": "integer", "description": "From 1 to 10"}
+			},
+			"required": ["joke", "rating"],
+			"additionalProperties": false
+		}`, resp.Result.Value().(types.String).ValueString()) //nolint:forcetypeassert
+	})
+
+	t.Run("test that an invalid output format is an argument error", func(t *testing.T) {
+		resp := runTestFunction(t, NewOutputFormatToJSONSchemaFunction(), types.StringUnknown(),
+			types.MapValueMust(types.StringType, map[string]attr.Value{
+				"joke": types.StringValue("string"),
+			}))
+		assert.Equal(t, function.NewArgumentFuncError(0, `output field "joke": unknown type "string", expected one of str, int, float, bool, list, object`), resp.Error)
+	})
+}
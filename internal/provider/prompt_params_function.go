@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &PromptParamsFunction{}
+
+func NewPromptParamsFunction() function.Function {
+	return &PromptParamsFunction{}
+}
+
+// PromptParamsFunction defines the function implementation.
+type PromptParamsFunction struct{}
+
+func (f *PromptParamsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "prompt_params"
+}
+
+func (f *PromptParamsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Lists the parameters of a prompt",
+		MarkdownDescription: "Returns the distinct names of the `{param}` placeholders in a prompt, in the order in which they first appear. Doubled braces, `{{` and `}}`, are literals. This is the same list the `rightbrain_task` resource reports in `input_params`.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "user_prompt",
+				Description: "The prompt template to parse.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *PromptParamsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var prompt string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &prompt))
+
+	if resp.Error != nil {
+		return
+	}
+
+	names, err := sdk.PromptParamNames(prompt)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, names))
+}
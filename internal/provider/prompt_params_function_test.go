@@ -0,0 +1,46 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptParamsFunction(t *testing.T) {
+
+	t.Run("test that it returns distinct params in order", func(t *testing.T) {
+		resp := runTestFunction(t, NewPromptParamsFunction(), types.ListUnknown(types.StringType),
+			types.StringValue(`Compare {a} with {b} and {a} as {{"json": true}}`))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("a"),
+			types.StringValue("b"),
+		}), resp.Result.Value())
+	})
+
+	t.Run("test that a prompt without params returns an empty list", func(t *testing.T) {
+		resp := runTestFunction(t, NewPromptParamsFunction(), types.ListUnknown(types.StringType),
+			types.StringValue("Tell me a joke"))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}), resp.Result.Value())
+	})
+
+	t.Run("test that a malformed prompt is an argument error", func(t *testing.T) {
+		resp := runTestFunction(t, NewPromptParamsFunction(), types.ListUnknown(types.StringType),
+			types.StringValue("Tell me about {subject"))
+		assert.Equal(t, function.NewArgumentFuncError(0, "invalid prompt template at offset 14: unclosed '{'"), resp.Error)
+	})
+}
+
+func runTestFunction(t *testing.T, f function.Function, result attr.Value, args ...attr.Value) *function.RunResponse {
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(context.Background(), function.RunRequest{Arguments: function.NewArgumentsData(args)}, resp)
+	return resp
+}
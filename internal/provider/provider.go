@@ -6,13 +6,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"time"
 
 	"terraform-provider-tasks/internal/sdk"
 
+	"github.com/benbjohnson/clock"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,11 +28,14 @@ import (
 var _ provider.Provider = &RightbrainProvider{}
 var _ provider.ProviderWithFunctions = &RightbrainProvider{}
 var _ provider.ProviderWithEphemeralResources = &RightbrainProvider{}
+var _ provider.ProviderWithListResources = &RightbrainProvider{}
 
 const (
 	ProviderName     = "rightbrain"
 	DefaultOAuthHost = "https://oauth.rightbrain.ai"
 	DefaultAPIHost   = "https://app.rightbrain.ai"
+
+	DefaultModelCacheTTL = 10 * time.Minute
 )
 
 // RightbrainProvider defines the provider implementation.
@@ -36,16 +44,20 @@ type RightbrainProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+	// clock is shared by the clients of the provider, so that tests can
+	// control how tokens and cached models age.
+	clock clock.Clock
 }
 
 // RightbrainProviderModel describes the provider data model.
 type RightbrainProviderModel struct {
-	RightbrainAPIHost      types.String `tfsdk:"api_host"`
-	RightbrainOAuthHost    types.String `tfsdk:"oauth_host"`
-	RightbrainClientID     types.String `tfsdk:"client_id"`
-	RightbrainClientSecret types.String `tfsdk:"client_secret"`
-	RightbrainOrgID        types.String `tfsdk:"org_id"`
-	RightbrainProjectID    types.String `tfsdk:"project_id"`
+	RightbrainAPIHost       types.String `tfsdk:"api_host"`
+	RightbrainOAuthHost     types.String `tfsdk:"oauth_host"`
+	RightbrainClientID      types.String `tfsdk:"client_id"`
+	RightbrainClientSecret  types.String `tfsdk:"client_secret"`
+	RightbrainOrgID         types.String `tfsdk:"org_id"`
+	RightbrainProjectID     types.String `tfsdk:"project_id"`
+	RightbrainModelCacheTTL types.String `tfsdk:"model_cache_ttl"`
 }
 
 func (p *RightbrainProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -80,6 +92,10 @@ func (p *RightbrainProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				MarkdownDescription: "The Project ID",
 				Required:            true,
 			},
+			"model_cache_ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the model catalog is reused for before it is requested again, as a duration such as `30s` or `10m`. Defaults to `10m`, set to `0s` to disable caching.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -90,46 +106,80 @@ func (p *RightbrainProvider) Configure(ctx context.Context, req provider.Configu
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	client, err := p.newRightbrainClient(data)
+	modelCacheTTL := DefaultModelCacheTTL
+	if !data.RightbrainModelCacheTTL.IsNull() {
+		var err error
+		modelCacheTTL, err = time.ParseDuration(data.RightbrainModelCacheTTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("model_cache_ttl"), "invalid model_cache_ttl", err.Error())
+			return
+		}
+	}
+	client, err := p.newRightbrainClient(data, modelCacheTTL)
 	if err != nil {
 		resp.Diagnostics.AddError("cannot create rightbrain client", err.Error())
 		return
 	}
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.ListResourceData = client
+	resp.EphemeralResourceData = client
 }
 
 func (p *RightbrainProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewTaskResource,
+		NewTaskForwarderResource,
+		NewTaskEvaluationResource,
+	}
+}
+
+func (p *RightbrainProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewTaskListResource,
 	}
 }
 
 func (p *RightbrainProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewOAuthTokenEphemeralResource,
+		NewTaskAccessTokenEphemeralResource,
+		NewTaskRunEphemeralResource,
+	}
 }
 
 func (p *RightbrainProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewLLMModelDataSource,
+		NewLLMModelsDataSource,
+		NewTaskDataSource,
+		NewTasksDataSource,
+		NewTaskRevisionDataSource,
 	}
 }
 
 func (p *RightbrainProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewOutputFormatFromJSONSchemaFunction,
+		NewOutputFormatToJSONSchemaFunction,
+		NewPromptParamsFunction,
+		NewRenderPromptFunction,
+		NewValidateOutputFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &RightbrainProvider{
 			version: version,
+			clock:   clock.New(),
 		}
 	}
 }
 
-func (p *RightbrainProvider) newRightbrainClient(data RightbrainProviderModel) (*sdk.TasksClient, error) {
+func (p *RightbrainProvider) newRightbrainClient(data RightbrainProviderModel, modelCacheTTL time.Duration) (*sdk.TasksClient, error) {
 	oauthURL := fmt.Sprintf("%s/oauth2/token", p.isEmptyValueElseDefault(data.RightbrainOAuthHost, DefaultOAuthHost))
-	tokenStore, err := sdk.NewDefaultTokenStore(oauthURL)
+	tokenStore, err := sdk.NewTokenStore(slog.Default().With("component", "TokenStore"), p.clock, http.DefaultClient, oauthURL)
 	if err != nil {
 		return nil, err
 	}
@@ -139,6 +189,8 @@ func (p *RightbrainProvider) newRightbrainClient(data RightbrainProviderModel) (
 		RightbrainClientSecret: data.RightbrainClientSecret.ValueString(),
 		RightbrainOrgID:        data.RightbrainOrgID.ValueString(),
 		RightbrainProjectID:    data.RightbrainProjectID.ValueString(),
+		ModelCacheTTL:          modelCacheTTL,
+		Clock:                  p.clock,
 	}), nil
 }
 
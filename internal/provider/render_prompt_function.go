@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RenderPromptFunction{}
+
+func NewRenderPromptFunction() function.Function {
+	return &RenderPromptFunction{}
+}
+
+// RenderPromptFunction defines the function implementation.
+type RenderPromptFunction struct{}
+
+func (f *RenderPromptFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_prompt"
+}
+
+func (f *RenderPromptFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Renders a prompt template",
+		MarkdownDescription: "Substitutes the value of each `{param}` placeholder in a prompt template and turns doubled braces, `{{` and `}}`, back into literal braces, as Rightbrain does when a Task is run. Every placeholder must have a value, values that the template does not use are ignored.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "template",
+				Description: "The prompt template to render.",
+			},
+			function.MapParameter{
+				Name:        "vars",
+				ElementType: types.StringType,
+				Description: "The values of the placeholders, keyed by parameter name.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RenderPromptFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	var vars map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &template, &vars))
+
+	if resp.Error != nil {
+		return
+	}
+
+	prompt, err := sdk.RenderPrompt(template, vars)
+	if err != nil {
+		var syntaxErr *sdk.PromptSyntaxError
+		if errors.As(err, &syntaxErr) {
+			resp.Error = function.NewArgumentFuncError(0, err.Error())
+		} else {
+			resp.Error = function.NewArgumentFuncError(1, err.Error())
+		}
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, prompt))
+}
@@ -0,0 +1,39 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPromptFunction(t *testing.T) {
+
+	vars := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"subject": types.StringValue("chickens"),
+	})
+
+	t.Run("test that it renders the template", func(t *testing.T) {
+		resp := runTestFunction(t, NewRenderPromptFunction(), types.StringUnknown(),
+			types.StringValue(`Tell me about {subject} as {{"joke": "..."}}`), vars)
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.StringValue(`Tell me about chickens as {"joke": "..."}`), resp.Result.Value())
+	})
+
+	t.Run("test that missing variables are an error", func(t *testing.T) {
+		resp := runTestFunction(t, NewRenderPromptFunction(), types.StringUnknown(),
+			types.StringValue("Tell me about {subject} in {language}"), vars)
+		assert.Equal(t, function.NewArgumentFuncError(1, "missing values for prompt parameters: language"), resp.Error)
+	})
+
+	t.Run("test that a malformed template is an error", func(t *testing.T) {
+		resp := runTestFunction(t, NewRenderPromptFunction(), types.StringUnknown(),
+			types.StringValue("Tell me about subject}"), vars)
+		assert.Equal(t, function.NewArgumentFuncError(0, "invalid prompt template at offset 21: single '}' encountered"), resp.Error)
+	})
+}
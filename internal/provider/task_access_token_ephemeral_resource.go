@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &TaskAccessTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &TaskAccessTokenEphemeralResource{}
+
+func NewTaskAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &TaskAccessTokenEphemeralResource{}
+}
+
+// TaskAccessTokenEphemeralResource defines the ephemeral resource
+// implementation.
+type TaskAccessTokenEphemeralResource struct {
+	client *sdk.TasksClient
+}
+
+// TaskAccessTokenEphemeralResourceModel describes the ephemeral resource data
+// model.
+type TaskAccessTokenEphemeralResourceModel struct {
+	TaskID      types.String `tfsdk:"task_id"`
+	AccessToken types.String `tfsdk:"access_token"`
+}
+
+func (e *TaskAccessTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_access_token"
+}
+
+func (e *TaskAccessTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The access token of a public Task. The token is never stored in the plan or state.",
+
+		Attributes: map[string]schema.Attribute{
+			"task_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Task.",
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token that callers present to run the Task.",
+			},
+		},
+	}
+}
+
+func (e *TaskAccessTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *TaskAccessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TaskAccessTokenEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	task, err := e.client.Fetch(ctx, sdk.NewFetchTaskRequest(data.TaskID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	if task.AccessToken == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("task_id"), fmt.Sprintf("task %q has no access token, only public tasks have one", task.ID), "")
+		return
+	}
+
+	data.AccessToken = types.StringValue(task.AccessToken)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskAccessTokenEphemeralResource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	public := api.addTask("Public joke")
+	public.Public = true
+	public.AccessToken = "task-access-token"
+	private := api.addTask("Private joke")
+
+	t.Run("test that it opens with the access token of a public task", func(t *testing.T) {
+		data, diags := openTestEphemeralResource(t, &TaskAccessTokenEphemeralResource{client: api.client()}, TaskAccessTokenEphemeralResourceModel{TaskID: types.StringValue(public.ID)})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue("task-access-token"), data.AccessToken)
+	})
+
+	t.Run("test that tasks without an access token are an error", func(t *testing.T) {
+		_, diags := openTestEphemeralResource(t, &TaskAccessTokenEphemeralResource{client: api.client()}, TaskAccessTokenEphemeralResourceModel{TaskID: types.StringValue(private.ID)})
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, `task "`+private.ID+`" has no access token, only public tasks have one`, diags[0].Summary())
+	})
+}
@@ -0,0 +1,312 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TaskDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &TaskDataSource{}
+
+func NewTaskDataSource() datasource.DataSource {
+	return &TaskDataSource{}
+}
+
+// TaskDataSource defines the data source implementation.
+type TaskDataSource struct {
+	client *sdk.TasksClient
+}
+
+// TaskDataSourceModel describes the data source data model.
+type TaskDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	ProjectID       types.String `tfsdk:"project_id"`
+	Description     types.String `tfsdk:"description"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	Public          types.Bool   `tfsdk:"public"`
+	ExposedToAgents types.Bool   `tfsdk:"exposed_to_agents"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	UpdatedAt       types.String `tfsdk:"updated_at"`
+
+	ActiveRevisionID types.String            `tfsdk:"active_revision_id"`
+	SystemPrompt     types.String            `tfsdk:"system_prompt"`
+	UserPrompt       types.String            `tfsdk:"user_prompt"`
+	LLMModelID       types.String            `tfsdk:"llm_model_id"`
+	ImageRequired    types.Bool              `tfsdk:"image_required"`
+	OptimiseImages   types.Bool              `tfsdk:"optimise_images"`
+	OutputFormat     map[string]types.String `tfsdk:"output_format"`
+	OutputModality   types.String            `tfsdk:"output_modality"`
+	InputParams      types.List              `tfsdk:"input_params"`
+	TaskForwarderID  types.String            `tfsdk:"task_forwarder_id"`
+
+	Revisions []TaskDataSourceRevisionModel `tfsdk:"revisions"`
+}
+
+type TaskDataSourceRevisionModel struct {
+	ID         types.String `tfsdk:"id"`
+	Active     types.Bool   `tfsdk:"active"`
+	LLMModelID types.String `tfsdk:"llm_model_id"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	UpdatedAt  types.String `tfsdk:"updated_at"`
+}
+
+func (tdsm *TaskDataSourceModel) PopulateFromTaskEntity(task *entitites.Task) error {
+	rev, err := task.GetActiveRevision()
+	if err != nil {
+		return err
+	}
+
+	tdsm.ID = types.StringValue(task.ID)
+	tdsm.Name = types.StringValue(task.Name)
+	tdsm.ProjectID = types.StringValue(task.ProjectID)
+	tdsm.Description = types.StringValue(task.Description)
+	tdsm.Enabled = types.BoolValue(task.Enabled)
+	tdsm.Public = types.BoolValue(task.Public)
+	tdsm.ExposedToAgents = types.BoolValue(task.ExposedToAgents)
+	tdsm.CreatedAt = timeToStringValue(task.Created)
+	tdsm.UpdatedAt = timeToStringValue(task.Modified)
+
+	tdsm.ActiveRevisionID = types.StringValue(rev.ID)
+	tdsm.SystemPrompt = types.StringValue(rev.SystemPrompt)
+	tdsm.UserPrompt = types.StringValue(rev.UserPrompt)
+	tdsm.LLMModelID = types.StringValue(rev.LLMModelID)
+	tdsm.ImageRequired = types.BoolValue(rev.ImageRequired)
+	tdsm.OptimiseImages = types.BoolValue(rev.OptimiseImages)
+	tdsm.OutputModality = types.StringValue(rev.OutputModality)
+	tdsm.InputParams = inputParamsToListValue(rev.InputParams)
+	tdsm.TaskForwarderID = types.StringNull()
+	if rev.TaskForwarderID != "" {
+		tdsm.TaskForwarderID = types.StringValue(rev.TaskForwarderID)
+	}
+
+	tdsm.OutputFormat = outputFormatToMap(rev.OutputFormat, nil)
+
+	tdsm.Revisions = make([]TaskDataSourceRevisionModel, len(task.Revisions))
+	for i, r := range task.Revisions {
+		tdsm.Revisions[i] = TaskDataSourceRevisionModel{
+			ID:         types.StringValue(r.ID),
+			Active:     types.BoolValue(r.Active),
+			LLMModelID: types.StringValue(r.LLMModelID),
+			CreatedAt:  timeToStringValue(r.Created),
+			UpdatedAt:  timeToStringValue(r.Modified),
+		}
+	}
+
+	return nil
+}
+
+func (d *TaskDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task"
+}
+
+func (d *TaskDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Task data source. Looks up a Task by `id` or by `name`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the Task. Must match exactly one Task in the project.",
+			},
+			"project_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the project the Task belongs to.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "A description of the Task.",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "When `true` the Task is active and callable.",
+			},
+			"public": schema.BoolAttribute{
+				Computed: true,
+			},
+			"exposed_to_agents": schema.BoolAttribute{
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the Task was created.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the Task was last modified.",
+			},
+			"active_revision_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"system_prompt": schema.StringAttribute{
+				Computed:    true,
+				Description: "The system prompt of the active revision.",
+			},
+			"user_prompt": schema.StringAttribute{
+				Computed:    true,
+				Description: "The user prompt of the active revision.",
+			},
+			"llm_model_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the LLM model used by the active revision.",
+			},
+			"image_required": schema.BoolAttribute{
+				Computed: true,
+			},
+			"optimise_images": schema.BoolAttribute{
+				Computed: true,
+			},
+			"output_format": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The output format of the active revision. Fields described by an object are encoded as JSON.",
+			},
+			"output_modality": schema.StringAttribute{
+				Computed: true,
+			},
+			"input_params": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the parameters that must be supplied when running the Task.",
+			},
+			"task_forwarder_id": schema.StringAttribute{
+				Computed: true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Every revision of the Task, most recent first.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"active": schema.BoolAttribute{
+							Computed: true,
+						},
+						"llm_model_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"created_at": schema.StringAttribute{
+							Computed: true,
+						},
+						"updated_at": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TaskDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *TaskDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TaskDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TaskDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.ID.ValueString()
+
+	if data.ID.IsNull() {
+		var err error
+		id, err = findTaskIDByName(ctx, d.client, data.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), err.Error(), "")
+			return
+		}
+	}
+
+	task, err := d.client.Fetch(ctx, sdk.NewFetchTaskRequest(id))
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	if err := data.PopulateFromTaskEntity(task); err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findTaskIDByName returns the ID of the only task in the project called name.
+func findTaskIDByName(ctx context.Context, client *sdk.TasksClient, name string) (string, error) {
+	var matches []string
+
+	in := sdk.NewListTasksRequest()
+	in.Name = name
+
+	for task, err := range client.AllTasks(ctx, in) {
+		if err != nil {
+			return "", err
+		}
+		if task.Name == name {
+			matches = append(matches, task.ID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("cannot find task named %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("more than one task is named %q, use one of the IDs instead: %s", name, strings.Join(matches, ", "))
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskDataSource(t *testing.T) {
+
+	t.Run("test that it finds a task by name across pages", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		for i := 0; i < 150; i++ {
+			api.addTask("Filler")
+		}
+		task := api.addTask("Joke")
+
+		data, diags := readTestDataSource(t, &TaskDataSource{client: api.client()}, TaskDataSourceModel{Name: types.StringValue("Joke"), InputParams: types.ListNull(types.StringType)})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(task.ID), data.ID)
+		assert.Equal(t, types.StringValue(task.Revisions[0].ID), data.ActiveRevisionID)
+		assert.Equal(t, types.StringValue("Tell me about {subject}"), data.UserPrompt)
+		assert.Len(t, data.Revisions, 1)
+	})
+
+	t.Run("test that it finds a task by id", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		task := api.addTask("Joke")
+
+		data, diags := readTestDataSource(t, &TaskDataSource{client: api.client()}, TaskDataSourceModel{ID: types.StringValue(task.ID), InputParams: types.ListNull(types.StringType)})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue("Joke"), data.Name)
+	})
+
+	t.Run("test that it encodes output field descriptions as JSON", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		task := api.addTask("Joke")
+		task.Revisions[0].OutputFormat = entitites.OutputFormat{
+			"joke":   "str",
+			"rating": map[string]any{"type": "int", "description": "How funny it is"},
+		}
+
+		data, diags := readTestDataSource(t, &TaskDataSource{client: api.client()}, TaskDataSourceModel{ID: types.StringValue(task.ID), InputParams: types.ListNull(types.StringType)})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, map[string]types.String{
+			"joke":   types.StringValue("str"),
+			"rating": types.StringValue(`{"description":"How funny it is","type":"int"}`),
+		}, data.OutputFormat)
+	})
+
+	t.Run("test that duplicate names are an error", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		first := api.addTask("Joke")
+		second := api.addTask("Joke")
+		api.addTask("Joke of the day")
+
+		_, diags := readTestDataSource(t, &TaskDataSource{client: api.client()}, TaskDataSourceModel{Name: types.StringValue("Joke"), InputParams: types.ListNull(types.StringType)})
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, `more than one task is named "Joke", use one of the IDs instead: `+first.ID+", "+second.ID, diags[0].Summary())
+	})
+}
@@ -0,0 +1,647 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TaskEvaluationResource{}
+var _ resource.ResourceWithModifyPlan = &TaskEvaluationResource{}
+
+func NewTaskEvaluationResource() resource.Resource {
+	return &TaskEvaluationResource{}
+}
+
+// TaskEvaluationResource defines the resource implementation. Every case is
+// a billed run of the task, so the cases are only run by Create and Update,
+// and ModifyPlan plans an update only when the results could differ.
+type TaskEvaluationResource struct {
+	client *sdk.TasksClient
+}
+
+// TaskEvaluationResourceModel describes the resource data model.
+type TaskEvaluationResourceModel struct {
+	TaskID            types.String              `tfsdk:"task_id"`
+	CheckOutputFormat types.Bool                `tfsdk:"check_output_format"`
+	FailOnFailure     types.Bool                `tfsdk:"fail_on_failure"`
+	Cases             []TaskEvaluationCaseModel `tfsdk:"case"`
+
+	RevisionID types.String `tfsdk:"revision_id"`
+	Passed     types.Bool   `tfsdk:"passed"`
+	Results    types.List   `tfsdk:"results"`
+}
+
+// TaskEvaluationCaseModel describes a single input case and the constraints
+// its output must satisfy.
+type TaskEvaluationCaseModel struct {
+	Name      types.String                `tfsdk:"name"`
+	Inputs    map[string]types.String     `tfsdk:"inputs"`
+	ImagePath types.String                `tfsdk:"image_path"`
+	Expect    []TaskEvaluationExpectModel `tfsdk:"expect"`
+}
+
+// TaskEvaluationExpectModel describes a constraint on the value at a path in
+// the output.
+type TaskEvaluationExpectModel struct {
+	Path    types.String `tfsdk:"path"`
+	Equals  types.String `tfsdk:"equals"`
+	Matches types.String `tfsdk:"matches"`
+	Type    types.String `tfsdk:"type"`
+}
+
+// TaskEvaluationResultModel describes the outcome of a single case.
+type TaskEvaluationResultModel struct {
+	Name     types.String   `tfsdk:"name"`
+	Passed   types.Bool     `tfsdk:"passed"`
+	Output   types.String   `tfsdk:"output"`
+	Failures []types.String `tfsdk:"failures"`
+}
+
+var taskEvaluationResultType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":     types.StringType,
+	"passed":   types.BoolType,
+	"output":   types.StringType,
+	"failures": types.ListType{ElemType: types.StringType},
+}}
+
+func (r *TaskEvaluationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_evaluation"
+}
+
+func (r *TaskEvaluationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Task evaluation resource. Runs the active revision of a Task once for each `case` and checks every output against its `expect` constraints. By default any failing case fails the apply with a report of every failure.\n\n" +
+			"Every case is a billed run of the Task. The cases run when the resource is created, and again only when `task_id`, `check_output_format`, the cases or the active revision of the Task change. Plans and refreshes never run the Task, so failing cases are reported by the apply, and by a plan only when `fail_on_failure` is switched on for results already in state. A change to a Task in the same apply is only evaluated by the next one, unless the `active_revision_id` of the Task is listed in `replace_triggered_by`.",
+
+		Attributes: map[string]schema.Attribute{
+			"task_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Task to evaluate.",
+			},
+			"check_output_format": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether every output must also conform to the `output_format` of the active revision. Defaults to `true`.",
+			},
+			"fail_on_failure": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether a failing case is an error. When `false` failures are only reported in `results`, for use in `check` blocks. Defaults to `true`.",
+			},
+			"revision_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the revision that was evaluated.",
+			},
+			"passed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether every case passed.",
+			},
+			"results": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The outcome of each case, in the order the cases are declared.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"passed": schema.BoolAttribute{
+							Computed: true,
+						},
+						"output": schema.StringAttribute{
+							Computed:    true,
+							Description: "The output of the run as a JSON string.",
+						},
+						"failures": schema.ListAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "A description of each constraint the output did not satisfy.",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"case": schema.ListNestedBlock{
+				Description: "An input case to run the Task with.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:    true,
+							Description: "The name of the case, used in the report.",
+						},
+						"inputs": schema.MapAttribute{
+							Optional:    true,
+							ElementType: types.StringType,
+							Description: "The values of the prompt parameters, keyed by parameter name.",
+						},
+						"image_path": schema.StringAttribute{
+							Optional:    true,
+							Description: "The path of an image file to upload with the inputs.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"expect": schema.ListNestedBlock{
+							Description: "A constraint on the output of the case.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"path": schema.StringAttribute{
+										Optional:            true,
+										MarkdownDescription: "The path of the value to check, such as `author.name` or `tags[0]`. Defaults to the whole output.",
+									},
+									"equals": schema.StringAttribute{
+										Optional:            true,
+										MarkdownDescription: "The JSON encoding of the exact value expected, such as `jsonencode(\"positive\")`.",
+										Validators: []validator.String{
+											stringvalidator.AtLeastOneOf(
+												path.MatchRelative().AtParent().AtName("matches"),
+												path.MatchRelative().AtParent().AtName("type"),
+											),
+										},
+									},
+									"matches": schema.StringAttribute{
+										Optional:    true,
+										Description: "A regular expression that the value, which must be a string, has to match.",
+									},
+									"type": schema.StringAttribute{
+										Optional:            true,
+										MarkdownDescription: "The output format type that the value must have, one of `str`, `int`, `float`, `bool`, `list` or `object`.",
+										Validators: []validator.String{
+											stringvalidator.OneOf(sdk.OutputFieldTypes...),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TaskEvaluationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan keeps the results in state unless running the cases again could
+// change them, that is unless task_id, check_output_format, the cases or the
+// active revision of the task changed.
+func (r *TaskEvaluationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// the results of a new evaluation are unknown until it runs, and there is
+	// nothing to keep when it is destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	changed := false
+	for _, name := range []string{"task_id", "check_output_format", "case"} {
+		var prior, planned attr.Value
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		changed = changed || !planned.Equal(prior)
+	}
+
+	var taskID, revisionID types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("task_id"), &taskID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("revision_id"), &revisionID)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if changed || r.activeRevisionChanged(ctx, taskID.ValueString(), revisionID.ValueString()) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revision_id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("passed"), types.BoolUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("results"), types.ListUnknown(taskEvaluationResultType))...)
+		return
+	}
+
+	for _, name := range []string{"revision_id", "passed", "results"} {
+		var value attr.Value
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &value)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), value)...)
+	}
+
+	// the results in state are kept, so failures that fail_on_failure now
+	// turns into an error are already known
+	var kept TaskEvaluationResourceModel
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("fail_on_failure"), &kept.FailOnFailure)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("revision_id"), &kept.RevisionID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("results"), &kept.Results)...)
+
+	if resp.Diagnostics.HasError() || kept.FailOnFailure.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(checkTaskEvaluation(ctx, kept)...)
+}
+
+// activeRevisionChanged reports whether the active revision of the task is no
+// longer the one that was evaluated. A task that cannot be fetched counts as
+// changed, so that the apply reports why.
+func (r *TaskEvaluationResource) activeRevisionChanged(ctx context.Context, taskID string, revisionID string) bool {
+	// the provider may not be configured yet, e.g. when its own configuration
+	// depends on values that are unknown until apply.
+	if r.client == nil {
+		return true
+	}
+
+	task, err := r.client.Fetch(ctx, sdk.NewFetchTaskRequest(taskID))
+	if err != nil {
+		return true
+	}
+	rev, err := task.GetActiveRevision()
+	if err != nil {
+		return true
+	}
+	return rev.ID != revisionID
+}
+
+func (r *TaskEvaluationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TaskEvaluationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.evaluate(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Read keeps the results in state as they are, as refreshing them would run
+// every case again.
+func (r *TaskEvaluationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+func (r *TaskEvaluationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TaskEvaluationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan only leaves the results unknown when the cases have to run
+	// again, otherwise fail_on_failure is applied to the results in state
+	if data.Results.IsUnknown() {
+		resp.Diagnostics.Append(r.evaluate(ctx, &data)...)
+	} else {
+		resp.Diagnostics.Append(checkTaskEvaluation(ctx, data)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only forgets the evaluation, there is nothing to remove in the API.
+func (r *TaskEvaluationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// evaluate runs every case of data against the active revision of the task
+// and records the results in data.
+func (r *TaskEvaluationResource) evaluate(ctx context.Context, data *TaskEvaluationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// check every constraint before spending any runs
+	expectations := make([][]expectation, len(data.Cases))
+	for i, c := range data.Cases {
+		for j, e := range c.Expect {
+			exp, expDiags := newExpectation(path.Root("case").AtListIndex(i).AtName("expect").AtListIndex(j), e)
+			diags.Append(expDiags...)
+			expectations[i] = append(expectations[i], exp)
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	task, err := r.client.Fetch(ctx, sdk.NewFetchTaskRequest(data.TaskID.ValueString()))
+	if err != nil {
+		diags.AddError(err.Error(), "")
+		return diags
+	}
+	rev, err := task.GetActiveRevision()
+	if err != nil {
+		diags.AddError(err.Error(), "")
+		return diags
+	}
+
+	var format map[string]sdk.OutputField
+	if data.CheckOutputFormat.IsNull() || data.CheckOutputFormat.ValueBool() {
+		format, err = sdk.ParseOutputFormat(rev.OutputFormat.StringMap())
+		if err != nil {
+			diags.AddAttributeError(path.Root("check_output_format"), "cannot check the output format of the active revision", err.Error())
+			return diags
+		}
+	}
+
+	passed := true
+	results := make([]TaskEvaluationResultModel, len(data.Cases))
+
+	for i, c := range data.Cases {
+		in := sdk.NewRunTaskRequest(task.ID)
+		for k, v := range c.Inputs {
+			in.Input[k] = v.ValueString()
+		}
+		if !c.ImagePath.IsNull() {
+			image, err := newRunTaskFile(c.ImagePath.ValueString())
+			if err != nil {
+				diags.AddAttributeError(path.Root("case").AtListIndex(i).AtName("image_path"), err.Error(), "")
+				return diags
+			}
+			in.Files = append(in.Files, image)
+		}
+
+		run, err := r.client.Run(ctx, in)
+		if err != nil {
+			diags.AddError(err.Error(), "")
+			return diags
+		}
+
+		failures := evaluateRun(run.IsError, run.Response, format, expectations[i])
+
+		result := TaskEvaluationResultModel{
+			Name:     c.Name,
+			Passed:   types.BoolValue(len(failures) == 0),
+			Output:   types.StringValue(string(run.Response)),
+			Failures: make([]types.String, len(failures)),
+		}
+		for j, f := range failures {
+			result.Failures[j] = types.StringValue(f)
+		}
+		results[i] = result
+		passed = passed && len(failures) == 0
+	}
+
+	data.RevisionID = types.StringValue(rev.ID)
+	data.Passed = types.BoolValue(passed)
+	var resultsDiags diag.Diagnostics
+	data.Results, resultsDiags = types.ListValueFrom(ctx, taskEvaluationResultType, results)
+	diags.Append(resultsDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return checkTaskEvaluation(ctx, *data)
+}
+
+// checkTaskEvaluation fails with a report of every failing case, unless
+// fail_on_failure is false.
+func checkTaskEvaluation(ctx context.Context, data TaskEvaluationResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.FailOnFailure.IsNull() && !data.FailOnFailure.ValueBool() {
+		return diags
+	}
+
+	var results []TaskEvaluationResultModel
+	diags.Append(data.Results.ElementsAs(ctx, &results, false)...)
+
+	var report strings.Builder
+	failed := 0
+	for _, result := range results {
+		if len(result.Failures) == 0 {
+			continue
+		}
+		failed++
+		fmt.Fprintf(&report, "\ncase %q:\n", result.Name.ValueString())
+		for _, f := range result.Failures {
+			fmt.Fprintf(&report, "  - %s\n", f.ValueString())
+		}
+	}
+
+	if failed > 0 {
+		diags.AddError(
+			"Task evaluation failed",
+			fmt.Sprintf("%d of %d cases failed against revision %s:\n%s", failed, len(results), data.RevisionID.ValueString(), report.String()),
+		)
+	}
+	return diags
+}
+
+// evaluateRun returns every way in which the response of a run fails to
+// conform to format, when set, and to the expectations.
+func evaluateRun(isError bool, response json.RawMessage, format map[string]sdk.OutputField, expectations []expectation) []string {
+	if isError {
+		return []string{fmt.Sprintf("the run failed: %s", response)}
+	}
+
+	output, err := sdk.DecodeOutput(response)
+	if err != nil {
+		return []string{fmt.Sprintf("the output is not valid JSON: %s", err)}
+	}
+
+	var failures []string
+	if format != nil {
+		for _, v := range sdk.ValidateOutput(format, output) {
+			failures = append(failures, "output_format: "+v)
+		}
+	}
+	for _, e := range expectations {
+		failures = append(failures, e.check(output)...)
+	}
+	return failures
+}
+
+// expectation is a validated expect block.
+type expectation struct {
+	path    outputPath
+	equals  any
+	matches *regexp.Regexp
+	typ     string
+
+	hasEquals bool
+}
+
+func newExpectation(p path.Path, e TaskEvaluationExpectModel) (expectation, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	exp := expectation{typ: e.Type.ValueString()}
+
+	var err error
+	if exp.path, err = parseOutputPath(e.Path.ValueString()); err != nil {
+		diags.AddAttributeError(p.AtName("path"), err.Error(), "")
+	}
+	if !e.Equals.IsNull() {
+		exp.hasEquals = true
+		if exp.equals, err = sdk.DecodeOutput([]byte(e.Equals.ValueString())); err != nil {
+			diags.AddAttributeError(p.AtName("equals"), "equals must be valid JSON", err.Error())
+		}
+	}
+	if !e.Matches.IsNull() {
+		if exp.matches, err = regexp.Compile(e.Matches.ValueString()); err != nil {
+			diags.AddAttributeError(p.AtName("matches"), err.Error(), "")
+		}
+	}
+
+	return exp, diags
+}
+
+// check returns a failure for every constraint that output does not satisfy.
+func (e expectation) check(output any) []string {
+	v, ok := e.path.lookup(output)
+	if !ok {
+		return []string{fmt.Sprintf("%s: not found", e.path)}
+	}
+
+	var failures []string
+	if e.typ != "" {
+		failures = append(failures, sdk.OutputField{Type: e.typ}.Validate(e.path.String(), v)...)
+	}
+	if e.hasEquals && !jsonEqual(e.equals, v) {
+		failures = append(failures, fmt.Sprintf("%s: expected %s, got %s", e.path, jsonString(e.equals), jsonString(v)))
+	}
+	if e.matches != nil {
+		if s, ok := v.(string); !ok {
+			failures = append(failures, fmt.Sprintf("%s: expected a string matching %q, got %s", e.path, e.matches, jsonString(v)))
+		} else if !e.matches.MatchString(s) {
+			failures = append(failures, fmt.Sprintf("%s: %s does not match %q", e.path, jsonString(v), e.matches))
+		}
+	}
+	return failures
+}
+
+// outputPath addresses a value in an output, as a sequence of object keys
+// and list indexes.
+type outputPath []any
+
+var outputPathSegmentPattern = regexp.MustCompile(`^([^.\[\]]+)((?:\[\d+\])*)$`)
+var outputPathIndexPattern = regexp.MustCompile(`\[(\d+)\]`)
+
+// parseOutputPath parses a path such as "author.name" or "tags[0]". An empty
+// path, or "$", addresses the whole output.
+func parseOutputPath(s string) (outputPath, error) {
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "$"), ".")
+	if s == "" {
+		return nil, nil
+	}
+
+	var p outputPath
+	for _, segment := range strings.Split(s, ".") {
+		m := outputPathSegmentPattern.FindStringSubmatch(segment)
+		if m == nil {
+			return nil, fmt.Errorf("invalid path segment %q", segment)
+		}
+		p = append(p, m[1])
+		for _, index := range outputPathIndexPattern.FindAllStringSubmatch(m[2], -1) {
+			i, err := strconv.Atoi(index[1])
+			if err != nil {
+				return nil, err
+			}
+			p = append(p, i)
+		}
+	}
+	return p, nil
+}
+
+func (p outputPath) lookup(v any) (any, bool) {
+	for _, segment := range p {
+		switch segment := segment.(type) {
+		case string:
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = obj[segment]; !ok {
+				return nil, false
+			}
+		case int:
+			list, ok := v.([]any)
+			if !ok || segment >= len(list) {
+				return nil, false
+			}
+			v = list[segment]
+		}
+	}
+	return v, true
+}
+
+func (p outputPath) String() string {
+	if len(p) == 0 {
+		return "output"
+	}
+	var sb strings.Builder
+	for _, segment := range p {
+		switch segment := segment.(type) {
+		case string:
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(segment)
+		case int:
+			fmt.Fprintf(&sb, "[%d]", segment)
+		}
+	}
+	return sb.String()
+}
+
+// jsonEqual compares two decoded JSON values, treating numbers as equal when
+// their values are, regardless of how they were written.
+func jsonEqual(a, b any) bool {
+	var na, nb any
+	if json.Unmarshal([]byte(jsonString(a)), &na) != nil || json.Unmarshal([]byte(jsonString(b)), &nb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
+
+func jsonString(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,256 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskEvaluationResource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	task := addTestEvaluatedTask(api)
+
+	chickens := TaskEvaluationCaseModel{
+		Name:   types.StringValue("chickens"),
+		Inputs: map[string]types.String{"subject": types.StringValue("chickens")},
+		Expect: []TaskEvaluationExpectModel{
+			{Path: types.StringValue("echo.subject"), Equals: types.StringValue(`"chickens"`)},
+			{Path: types.StringValue("$.echo.subject"), Matches: types.StringValue("^chick"), Type: types.StringValue("str")},
+		},
+	}
+	cows := TaskEvaluationCaseModel{
+		Name:   types.StringValue("cows"),
+		Inputs: map[string]types.String{"subject": types.StringValue("cows"), "mood": types.StringValue("happy")},
+		Expect: []TaskEvaluationExpectModel{
+			{Path: types.StringValue("echo.subject"), Equals: types.StringValue(`"chickens"`)},
+			{Path: types.StringValue("echo.missing[0]"), Type: types.StringValue("str")},
+		},
+	}
+
+	t.Run("test that passing cases are reported", func(t *testing.T) {
+		data, diags := createTestResource(t, newTestTaskEvaluationResource(api), newTestTaskEvaluationResourceModel(task.ID, chickens))
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.BoolValue(true), data.Passed)
+		assert.Equal(t, types.StringValue(task.Revisions[0].ID), data.RevisionID)
+
+		results := getTestTaskEvaluationResults(t, data)
+		assert.Len(t, results, 1)
+		assert.Equal(t, types.BoolValue(true), results[0].Passed)
+		assert.JSONEq(t, `{"echo": {"subject": "chickens"}}`, results[0].Output.ValueString())
+		assert.Empty(t, results[0].Failures)
+	})
+
+	t.Run("test that failures are reported per case", func(t *testing.T) {
+		plan := newTestTaskEvaluationResourceModel(task.ID, chickens, cows)
+		plan.FailOnFailure = types.BoolValue(false)
+
+		data, diags := createTestResource(t, newTestTaskEvaluationResource(api), plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.BoolValue(false), data.Passed)
+
+		results := getTestTaskEvaluationResults(t, data)
+		assert.Equal(t, types.BoolValue(true), results[0].Passed)
+		assert.Equal(t, types.BoolValue(false), results[1].Passed)
+		assert.Equal(t, []types.String{
+			types.StringValue("output_format: echo.mood: unexpected field"),
+			types.StringValue(`echo.subject: expected "chickens", got "cows"`),
+			types.StringValue("echo.missing[0]: not found"),
+		}, results[1].Failures)
+	})
+
+	t.Run("test that failing cases fail the apply by default", func(t *testing.T) {
+		_, diags := createTestResource(t, newTestTaskEvaluationResource(api), newTestTaskEvaluationResourceModel(task.ID, chickens, cows))
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "Task evaluation failed", diags[0].Summary())
+		assert.Equal(t, "1 of 2 cases failed against revision "+task.Revisions[0].ID+`:
+
+case "cows":
+  - output_format: echo.mood: unexpected field
+  - echo.subject: expected "chickens", got "cows"
+  - echo.missing[0]: not found
+`, diags[0].Detail())
+	})
+
+	t.Run("test that the output format check can be disabled", func(t *testing.T) {
+		plan := newTestTaskEvaluationResourceModel(task.ID, TaskEvaluationCaseModel{
+			Name:   types.StringValue("moody"),
+			Inputs: map[string]types.String{"subject": types.StringValue("chickens"), "mood": types.StringValue("happy")},
+		})
+		plan.CheckOutputFormat = types.BoolValue(false)
+
+		data, diags := createTestResource(t, newTestTaskEvaluationResource(api), plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.BoolValue(true), data.Passed)
+	})
+
+	t.Run("test that invalid constraints are rejected before running", func(t *testing.T) {
+		runs := len(api.runs)
+		_, diags := createTestResource(t, newTestTaskEvaluationResource(api), newTestTaskEvaluationResourceModel(task.ID, TaskEvaluationCaseModel{
+			Name: types.StringValue("broken"),
+			Expect: []TaskEvaluationExpectModel{
+				{Path: types.StringValue("echo..subject"), Matches: types.StringValue("(")},
+				{Equals: types.StringValue("not json")},
+			},
+		}))
+		assert.Equal(t, 3, diags.ErrorsCount())
+		assert.Equal(t, runs, len(api.runs))
+	})
+
+	t.Run("test that plans keep the results until they could change", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		task := addTestEvaluatedTask(api)
+		r := newTestTaskEvaluationResource(api)
+
+		state, diags := createTestResource(t, r, newTestTaskEvaluationResourceModel(task.ID, chickens))
+		assert.False(t, diags.HasError(), diags)
+		runs := len(api.runs)
+
+		// Terraform proposes the prior state when nothing changed
+		planned, diags := modifyTestResourcePlan(t, r, &state, state)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, state, planned)
+
+		// and unknown results when any of the configuration changed
+		plan := newTestTaskEvaluationResourceModel(task.ID, chickens)
+		plan.FailOnFailure = types.BoolValue(false)
+		planned, diags = modifyTestResourcePlan(t, r, &state, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, state.Results, planned.Results)
+
+		plan = newTestTaskEvaluationResourceModel(task.ID, chickens, chickens)
+		planned, diags = modifyTestResourcePlan(t, r, &state, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.True(t, planned.Results.IsUnknown())
+
+		rev := task.Revisions[0]
+		rev.ID = "019011e6-e530-3aca-6cf7-2973387c255e"
+		task.Revisions[0].Active = false
+		task.Revisions = append([]entitites.Revision{rev}, task.Revisions...)
+		planned, diags = modifyTestResourcePlan(t, r, &state, state)
+		assert.False(t, diags.HasError(), diags)
+		assert.True(t, planned.Results.IsUnknown())
+		assert.True(t, planned.RevisionID.IsUnknown())
+
+		assert.Equal(t, runs, len(api.runs))
+	})
+
+	t.Run("test that plans report kept failures and tolerate an unconfigured provider", func(t *testing.T) {
+		r := newTestTaskEvaluationResource(api)
+
+		prior := newTestTaskEvaluationResourceModel(task.ID, chickens, cows)
+		prior.FailOnFailure = types.BoolValue(false)
+		prior, diags := createTestResource(t, r, prior)
+		assert.False(t, diags.HasError(), diags)
+
+		plan := prior
+		plan.FailOnFailure = types.BoolValue(true)
+		_, diags = modifyTestResourcePlan(t, r, &prior, plan)
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "Task evaluation failed", diags[0].Summary())
+
+		planned, diags := modifyTestResourcePlan(t, &TaskEvaluationResource{}, &prior, prior)
+		assert.False(t, diags.HasError(), diags)
+		assert.True(t, planned.Results.IsUnknown())
+	})
+
+	t.Run("test that update only runs the cases when the results are unknown", func(t *testing.T) {
+		r := newTestTaskEvaluationResource(api)
+
+		prior := newTestTaskEvaluationResourceModel(task.ID, chickens, cows)
+		prior.FailOnFailure = types.BoolValue(false)
+		prior, diags := createTestResource(t, r, prior)
+		assert.False(t, diags.HasError(), diags)
+		runs := len(api.runs)
+
+		plan := prior
+		plan.FailOnFailure = types.BoolValue(true)
+		_, diags = updateTestResource(t, r, prior, plan)
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "Task evaluation failed", diags[0].Summary())
+		assert.Equal(t, runs, len(api.runs))
+
+		plan = newTestTaskEvaluationResourceModel(task.ID, chickens)
+		state, diags := updateTestResource(t, r, prior, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.BoolValue(true), state.Passed)
+		assert.Equal(t, runs+1, len(api.runs))
+	})
+}
+
+func newTestTaskEvaluationResource(api *fakeTasksAPI) *TaskEvaluationResource {
+	return &TaskEvaluationResource{client: api.client()}
+}
+
+// addTestEvaluatedTask adds a task with an output format that the echoed
+// input of a run conforms to.
+func addTestEvaluatedTask(api *fakeTasksAPI) *entitites.Task {
+	task := api.addTask("Tell me a joke")
+	task.Revisions[0].OutputFormat = entitites.OutputFormat{
+		"echo": map[string]any{"type": "object", "nested_structure": map[string]any{"subject": "str"}},
+	}
+	return task
+}
+
+func newTestTaskEvaluationResourceModel(taskID string, cases ...TaskEvaluationCaseModel) TaskEvaluationResourceModel {
+	return TaskEvaluationResourceModel{
+		TaskID:     types.StringValue(taskID),
+		Cases:      cases,
+		RevisionID: types.StringUnknown(),
+		Passed:     types.BoolUnknown(),
+		Results:    types.ListUnknown(taskEvaluationResultType),
+	}
+}
+
+func getTestTaskEvaluationResults(t *testing.T, data TaskEvaluationResourceModel) []TaskEvaluationResultModel {
+	var results []TaskEvaluationResultModel
+	assert.False(t, data.Results.ElementsAs(context.Background(), &results, false).HasError())
+	return results
+}
+
+func TestOutputPath(t *testing.T) {
+
+	output := map[string]any{
+		"tags":   []any{"a", map[string]any{"name": "b"}},
+		"author": map[string]any{"name": "Anon"},
+	}
+
+	t.Run("test that it looks up keys and indexes", func(t *testing.T) {
+		for path, expected := range map[string]any{
+			"author.name":  "Anon",
+			"tags[0]":      "a",
+			"tags[1].name": "b",
+			"$.tags[0]":    "a",
+			"":             output,
+		} {
+			p, err := parseOutputPath(path)
+			assert.NoError(t, err)
+			v, ok := p.lookup(output)
+			assert.True(t, ok, path)
+			assert.Equal(t, expected, v, path)
+		}
+	})
+
+	t.Run("test that missing values are not found", func(t *testing.T) {
+		for _, path := range []string{"title", "tags[2]", "author[0]", "author.name.first"} {
+			p, err := parseOutputPath(path)
+			assert.NoError(t, err)
+			_, ok := p.lookup(output)
+			assert.False(t, ok, path)
+		}
+	})
+
+	t.Run("test that malformed paths are rejected", func(t *testing.T) {
+		for _, path := range []string{"author..name", "tags[a]", "[0]", "tags]"} {
+			_, err := parseOutputPath(path)
+			assert.Error(t, err, path)
+		}
+	})
+}
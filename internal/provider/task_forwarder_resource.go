@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TaskForwarderResource{}
+var _ resource.ResourceWithImportState = &TaskForwarderResource{}
+
+func NewTaskForwarderResource() resource.Resource {
+	return &TaskForwarderResource{}
+}
+
+// TaskForwarderResource defines the resource implementation.
+type TaskForwarderResource struct {
+	client *sdk.TasksClient
+}
+
+// TaskForwarderResourceModel describes the resource data model.
+type TaskForwarderResourceModel struct {
+	ID                  types.String            `tfsdk:"id"`
+	Name                types.String            `tfsdk:"name"`
+	DestinationURL      types.String            `tfsdk:"destination_url"`
+	Headers             map[string]types.String `tfsdk:"headers"`
+	AuthSecretWO        types.String            `tfsdk:"auth_secret_wo"`
+	AuthSecretWOVersion types.Int64             `tfsdk:"auth_secret_wo_version"`
+}
+
+func (tfrm *TaskForwarderResourceModel) PopulateFromTaskForwarderEntity(forwarder *entitites.TaskForwarder) {
+	tfrm.ID = types.StringValue(forwarder.ID)
+	tfrm.Name = types.StringValue(forwarder.Name)
+	tfrm.DestinationURL = types.StringValue(forwarder.DestinationURL)
+
+	// keep an explicitly empty map as configured rather than flipping it to null
+	if len(forwarder.Headers) > 0 || tfrm.Headers != nil {
+		tfrm.Headers = make(map[string]types.String, len(forwarder.Headers))
+		for k, v := range forwarder.Headers {
+			tfrm.Headers[k] = types.StringValue(v)
+		}
+	}
+
+	// write-only values must never be persisted
+	tfrm.AuthSecretWO = types.StringNull()
+}
+
+func (r *TaskForwarderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_forwarder"
+}
+
+func (r *TaskForwarderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+
+		MarkdownDescription: "Task forwarder resource. Forwards the output of Task runs to an external endpoint.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "A name or reference for the Task forwarder.",
+			},
+			"destination_url": schema.StringAttribute{
+				Required:    true,
+				Description: "The URL that Task outputs are forwarded to.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Additional HTTP headers sent with each forwarded request. Headers may carry credentials, so they are hidden from plan output.",
+				ElementType: types.StringType,
+			},
+			"auth_secret_wo": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "The secret used to authenticate forwarded requests. This value is never stored in state.",
+			},
+			"auth_secret_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Change this value to send an updated `auth_secret_wo` to the API.",
+			},
+		},
+	}
+}
+
+func (r *TaskForwarderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TaskForwarderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TaskForwarderResourceModel
+	var authSecret types.String
+
+	// Read Terraform plan data into the model, write-only values are only
+	// available from the configuration.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_secret_wo"), &authSecret)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := sdk.NewCreateTaskForwarderRequest()
+	in.Name = data.Name.ValueString()
+	in.DestinationURL = data.DestinationURL.ValueString()
+	if !authSecret.IsNull() {
+		in.AuthSecret = authSecret.ValueStringPointer()
+	}
+
+	for k, v := range data.Headers {
+		in.Headers[k] = v.ValueString()
+	}
+
+	forwarder, err := r.client.CreateTaskForwarder(ctx, in)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	data.PopulateFromTaskForwarderEntity(forwarder)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TaskForwarderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TaskForwarderResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	forwarder, err := r.client.FetchTaskForwarder(ctx, sdk.NewFetchTaskForwarderRequest(data.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	data.PopulateFromTaskForwarderEntity(forwarder)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TaskForwarderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TaskForwarderResourceModel
+	var authSecret types.String
+	var priorVersion types.Int64
+
+	// Read Terraform plan data into the model, write-only values are only
+	// available from the configuration.
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_secret_wo"), &authSecret)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("auth_secret_wo_version"), &priorVersion)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := sdk.NewUpdateTaskForwarderRequest(data.ID.ValueString())
+	in.Name = data.Name.ValueString()
+	in.DestinationURL = data.DestinationURL.ValueString()
+	// the secret is only sent again when its version changes
+	if !authSecret.IsNull() && !data.AuthSecretWOVersion.Equal(priorVersion) {
+		in.AuthSecret = authSecret.ValueStringPointer()
+	}
+
+	for k, v := range data.Headers {
+		in.Headers[k] = v.ValueString()
+	}
+
+	forwarder, err := r.client.UpdateTaskForwarder(ctx, in)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	data.PopulateFromTaskForwarderEntity(forwarder)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TaskForwarderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TaskForwarderResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteTaskForwarder(ctx, sdk.NewDeleteTaskForwarderRequest(data.ID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+}
+
+func (r *TaskForwarderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
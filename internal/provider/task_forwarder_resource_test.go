@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskForwarderResource(t *testing.T) {
+
+	t.Run("test that create sends the secret without persisting it", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := &TaskForwarderResource{client: api.client()}
+
+		state, diags := createTestResource(t, r, newTestTaskForwarderResourceModel())
+		assert.False(t, diags.HasError(), diags)
+
+		body := api.lastBody()
+		assert.Equal(t, "s3cret", body["auth_secret"])
+		assert.Equal(t, map[string]any{"X-Source": "rightbrain"}, body["headers"])
+
+		assert.Equal(t, types.StringNull(), state.AuthSecretWO)
+		assert.Equal(t, types.Int64Value(1), state.AuthSecretWOVersion)
+		assert.Equal(t, types.StringValue("https://example.com/hook"), state.DestinationURL)
+		assert.Equal(t, api.forwarders[state.ID.ValueString()].Name, state.Name.ValueString())
+	})
+
+	t.Run("test that headers are sensitive", func(t *testing.T) {
+		s := getTestResourceSchema(t, &TaskForwarderResource{})
+		assert.True(t, s.Schema.Attributes["headers"].IsSensitive())
+	})
+
+	t.Run("test that update only sends the secret when its version changes", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := &TaskForwarderResource{client: api.client()}
+
+		prior, diags := createTestResource(t, r, newTestTaskForwarderResourceModel())
+		assert.False(t, diags.HasError(), diags)
+
+		plan := prior
+		plan.Name = types.StringValue("Renamed forwarder")
+		plan.AuthSecretWO = types.StringValue("s3cret")
+		prior, diags = updateTestResource(t, r, prior, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.NotContains(t, api.lastBody(), "auth_secret")
+		assert.Equal(t, types.StringValue("Renamed forwarder"), prior.Name)
+
+		plan = prior
+		plan.AuthSecretWO = types.StringValue("n3w-s3cret")
+		plan.AuthSecretWOVersion = types.Int64Value(2)
+		state, diags := updateTestResource(t, r, prior, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, "n3w-s3cret", api.lastBody()["auth_secret"])
+		assert.Equal(t, types.StringNull(), state.AuthSecretWO)
+		assert.Equal(t, types.Int64Value(2), state.AuthSecretWOVersion)
+	})
+
+	t.Run("test that read refreshes and delete removes the forwarder", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := &TaskForwarderResource{client: api.client()}
+		ctx := context.Background()
+		s := getTestResourceSchema(t, r)
+
+		state, diags := createTestResource(t, r, newTestTaskForwarderResourceModel())
+		assert.False(t, diags.HasError(), diags)
+		api.forwarders[state.ID.ValueString()].DestinationURL = "https://example.com/moved"
+
+		raw := tfsdk.State{Schema: s.Schema, Raw: newTestConfig(t, s.Schema, state)}
+		readResp := &resource.ReadResponse{State: raw}
+		r.Read(ctx, resource.ReadRequest{State: raw}, readResp)
+		assert.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+		var refreshed TaskForwarderResourceModel
+		assert.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+		assert.Equal(t, types.StringValue("https://example.com/moved"), refreshed.DestinationURL)
+		assert.Equal(t, types.StringNull(), refreshed.AuthSecretWO)
+
+		deleteResp := &resource.DeleteResponse{State: raw}
+		r.Delete(ctx, resource.DeleteRequest{State: raw}, deleteResp)
+		assert.False(t, deleteResp.Diagnostics.HasError(), deleteResp.Diagnostics)
+		assert.Empty(t, api.forwarders)
+	})
+}
+
+func newTestTaskForwarderResourceModel() TaskForwarderResourceModel {
+	return TaskForwarderResourceModel{
+		ID:                  types.StringUnknown(),
+		Name:                types.StringValue("Joke forwarder"),
+		DestinationURL:      types.StringValue("https://example.com/hook"),
+		Headers:             map[string]types.String{"X-Source": types.StringValue("rightbrain")},
+		AuthSecretWO:        types.StringValue("s3cret"),
+		AuthSecretWOVersion: types.Int64Value(1),
+	}
+}
@@ -6,13 +6,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"terraform-provider-tasks/internal/sdk"
 	entitites "terraform-provider-tasks/internal/sdk/entities"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -27,6 +32,9 @@ const TASK_SCHEMA_VERSION = 0
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TaskResource{}
 var _ resource.ResourceWithImportState = &TaskResource{}
+var _ resource.ResourceWithValidateConfig = &TaskResource{}
+var _ resource.ResourceWithModifyPlan = &TaskResource{}
+var _ resource.ResourceWithIdentity = &TaskResource{}
 
 func NewTaskResource() resource.Resource {
 	return &TaskResource{}
@@ -47,9 +55,15 @@ type InputProcessorModel struct {
 	Config         map[string]types.String `tfsdk:"config"`
 }
 
+type RAGModel struct {
+	CollectionID types.String `tfsdk:"collection_id"`
+	RAGParam     types.String `tfsdk:"rag_param"`
+}
+
 // TaskResourceModel describes the resource data model.
 type TaskResourceModel struct {
 	ID              types.String `tfsdk:"id"`
+	ProjectID       types.String `tfsdk:"project_id"`
 	Name            types.String `tfsdk:"name"`
 	Enabled         types.Bool   `tfsdk:"enabled"`
 	Public          types.Bool   `tfsdk:"public"`
@@ -64,8 +78,42 @@ type TaskResourceModel struct {
 	OutputModality  types.String            `tfsdk:"output_modality"`
 	InputProcessors *InputProcessorsModel   `tfsdk:"input_processors"`
 	OptimiseImages  types.Bool              `tfsdk:"optimise_images"`
+	RAG             *RAGModel               `tfsdk:"rag"`
+	TaskForwarderID types.String            `tfsdk:"task_forwarder_id"`
+	InputParams     types.List              `tfsdk:"input_params"`
 
 	ActiveRevisionID types.String `tfsdk:"active_revision_id"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+}
+
+// TaskIdentityModel describes the resource identity data model.
+type TaskIdentityModel struct {
+	OrgID     types.String `tfsdk:"org_id"`
+	ProjectID types.String `tfsdk:"project_id"`
+	ID        types.String `tfsdk:"id"`
+}
+
+// newTaskIdentityModel identifies task within the org the client is
+// configured for.
+func newTaskIdentityModel(client *sdk.TasksClient, task *entitites.Task) TaskIdentityModel {
+	projectID := task.ProjectID
+	if projectID == "" {
+		projectID = client.ProjectID()
+	}
+	return TaskIdentityModel{
+		OrgID:     types.StringValue(client.OrgID()),
+		ProjectID: types.StringValue(projectID),
+		ID:        types.StringValue(task.ID),
+	}
+}
+
+func (tim TaskIdentityModel) Equal(other TaskIdentityModel) bool {
+	return tim.OrgID.Equal(other.OrgID) && tim.ProjectID.Equal(other.ProjectID) && tim.ID.Equal(other.ID)
+}
+
+func (tim TaskIdentityModel) String() string {
+	return fmt.Sprintf("task %q in org %q, project %q", tim.ID.ValueString(), tim.OrgID.ValueString(), tim.ProjectID.ValueString())
 }
 
 func (trm *TaskResourceModel) HasInputProcessors() bool {
@@ -90,6 +138,9 @@ func (trm *TaskResourceModel) PopulateFromTaskEntity(task *entitites.Task) error
 	trm.UserPrompt = types.StringValue(rev.UserPrompt)
 	trm.LLMModelID = types.StringValue(rev.LLMModelID)
 	trm.ImageRequired = types.BoolValue(rev.ImageRequired)
+	if rev.OutputModality != "" {
+		trm.OutputModality = types.StringValue(rev.OutputModality)
+	}
 	trm.ExposedToAgents = types.BoolValue(task.ExposedToAgents)
 
 	if rev.HasInputProcessors() {
@@ -108,13 +159,70 @@ func (trm *TaskResourceModel) PopulateFromTaskEntity(task *entitites.Task) error
 		}
 	}
 
+	trm.OutputFormat = outputFormatToMap(rev.OutputFormat, trm.OutputFormat)
+	trm.InputParams = inputParamsToListValue(rev.InputParams)
+
+	trm.TaskForwarderID = types.StringNull()
+	if rev.TaskForwarderID != "" {
+		trm.TaskForwarderID = types.StringValue(rev.TaskForwarderID)
+	}
+
+	trm.RAG = nil
+	if rev.HasRAG() {
+		trm.RAG = &RAGModel{
+			CollectionID: types.StringValue(rev.RAG.CollectionID),
+			RAGParam:     types.StringValue(rev.RAG.RAGParam),
+		}
+	}
+
 	trm.ActiveRevisionID = types.StringValue(rev.ID)
+	trm.CreatedAt = timeToStringValue(task.Created)
+	trm.UpdatedAt = timeToStringValue(task.Modified)
 
 	return nil
 }
 
+// outputFormatToMap converts the output format returned by the API into the
+// map used by Terraform. Values in prior that describe the same field are kept
+// as they are, so that the formatting of JSON in the configuration is not
+// reported as a change.
+func outputFormatToMap(of entitites.OutputFormat, prior map[string]types.String) map[string]types.String {
+	result := make(map[string]types.String, len(of))
+	for k, v := range of.StringMap() {
+		result[k] = types.StringValue(v)
+		p, ok := prior[k]
+		if !ok || p.IsNull() || p.IsUnknown() {
+			continue
+		}
+		if configured, err := entitites.NewOutputFormat(map[string]string{k: p.ValueString()}); err == nil && configured.StringMap()[k] == v {
+			result[k] = p
+		}
+	}
+	return result
+}
+
+// inputParamsToListValue converts the params the API extracted from the user
+// prompt into input_params.
+func inputParamsToListValue(params []string) types.List {
+	elems := make([]attr.Value, len(params))
+	for i, p := range params {
+		elems[i] = types.StringValue(p)
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+// timeToStringValue formats t as RFC 3339, or null when the API omitted it.
+func timeToStringValue(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
+
 func (r *TaskResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_task"
+	// the org in the identity follows the provider configuration
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -131,6 +239,19 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"project_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "The ID of the project the Task belongs to, defaults to the project the provider is configured for.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					// state written before project_id existed has none to
+					// compare with, so it is filled in rather than replaced.
+					stringplanmodifier.RequiresReplaceIf(func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+						resp.RequiresReplace = !req.StateValue.IsNull()
+					}, "Moving a Task to another project requires replacement.", "Moving a Task to another project requires replacement."),
+				},
+			},
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "A name or reference for the Task.",
@@ -152,6 +273,17 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"active_revision_id": schema.StringAttribute{
 				Computed: true,
 			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the Task was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the Task was last modified.",
+			},
 			"exposed_to_agents": schema.BoolAttribute{
 				Optional:    true,
 				Description: "",
@@ -188,6 +320,15 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Default:     booldefault.StaticBool(true),
 				Computed:    true,
 			},
+			"input_params": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "The names of the `{param}` placeholders in `user_prompt` that must be supplied when running the Task.",
+			},
+			"task_forwarder_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "The ID of a `rightbrain_task_forwarder` that receives the output of each Task run.",
+			},
 			"output_modality": schema.StringAttribute{
 				Optional:    true,
 				Description: "Specifies the output modality of the task. Can be 'json' or 'image'",
@@ -219,10 +360,269 @@ func (r *TaskResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					},
 				},
 			},
+			"rag": schema.SingleNestedBlock{
+				Description: "Retrieval augmented generation settings for the Task.",
+				Attributes: map[string]schema.Attribute{
+					"collection_id": schema.StringAttribute{
+						Required:    true,
+						Description: "The ID of the document collection to retrieve context from.",
+					},
+					"rag_param": schema.StringAttribute{
+						Required:    true,
+						Description: "The `user_prompt` parameter that retrieved context is substituted into.",
+					},
+				},
+			},
 		},
 	}
 }
 
+func (r *TaskResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"org_id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The ID of the org the Task belongs to. Defaults to the org the provider is configured for.",
+			},
+			"project_id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The ID of the project the Task belongs to. Defaults to the project the provider is configured for.",
+			},
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "The ID of the Task.",
+			},
+		},
+	}
+}
+
+func (r *TaskResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var userPrompt, ragParam types.String
+	var processors types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("user_prompt"), &userPrompt)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rag").AtName("rag_param"), &ragParam)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("input_processors").AtName("input_processor"), &processors)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// input processors are compared against the prompt params, which are
+	// only known once the prompt is known and well formed. The API owns the
+	// prompt grammar, so findings that depend on it are only warnings.
+	var params []string
+	paramsKnown := false
+
+	if !userPrompt.IsNull() && !userPrompt.IsUnknown() {
+		var err error
+		params, err = sdk.PromptParamNames(userPrompt.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("user_prompt"),
+				"Possibly malformed prompt template",
+				fmt.Sprintf("%s. Use {param} for placeholders and {{ or }} for literal braces, or the API may not substitute the parameters as expected.", err.Error()),
+			)
+		} else {
+			paramsKnown = true
+		}
+	}
+
+	if paramsKnown && !ragParam.IsNull() && !ragParam.IsUnknown() && !slices.Contains(params, ragParam.ValueString()) {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("rag").AtName("rag_param"),
+			"RAG parameter not used in prompt",
+			fmt.Sprintf("The rag_param %q must appear as a {%s} placeholder in user_prompt.", ragParam.ValueString(), ragParam.ValueString()),
+		)
+	}
+
+	if processors.IsNull() || processors.IsUnknown() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, elem := range processors.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		paramName, ok := obj.Attributes()["param_name"].(types.String)
+		if !ok || paramName.IsNull() || paramName.IsUnknown() {
+			continue
+		}
+		name := paramName.ValueString()
+		attrPath := path.Root("input_processors").AtName("input_processor").AtListIndex(i).AtName("param_name")
+
+		if seen[name] {
+			resp.Diagnostics.AddAttributeError(
+				attrPath,
+				"Duplicate input processor parameter",
+				fmt.Sprintf("More than one input_processor is declared for param_name %q.", name),
+			)
+		}
+		seen[name] = true
+
+		if paramsKnown && !slices.Contains(params, name) {
+			resp.Diagnostics.AddAttributeWarning(
+				attrPath,
+				"Input processor parameter not used in prompt",
+				fmt.Sprintf("The param_name %q must appear as a {%s} placeholder in user_prompt.", name, name),
+			)
+		}
+	}
+}
+
+func (r *TaskResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to predict when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// only the attributes needed are read, as the rest of the plan may hold
+	// unknown values that cannot be converted into TaskResourceModel.
+	var data taskPlanCheckModel
+
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &data.ProjectID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("user_prompt"), &data.UserPrompt)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("llm_model_id"), &data.LLMModelID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image_required"), &data.ImageRequired)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("output_modality"), &data.OutputModality)...)
+
+	data.PriorUserPrompt = types.StringNull()
+	data.PriorInputParams = types.ListNull(types.StringType)
+	data.PriorLLMModelID = types.StringNull()
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("user_prompt"), &data.PriorUserPrompt)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("input_params"), &data.PriorInputParams)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("llm_model_id"), &data.PriorLLMModelID)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// tasks belong to the project the provider is configured for unless
+	// project_id says otherwise.
+	var configProjectID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("project_id"), &configProjectID)...)
+	if configProjectID.IsNull() && data.ProjectID.IsUnknown() && r.client != nil {
+		data.ProjectID = types.StringValue(r.client.ProjectID())
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("project_id"), data.ProjectID)...)
+	}
+
+	r.planInputParams(ctx, data, resp)
+	r.checkModelCapabilities(ctx, data, resp)
+}
+
+type taskPlanCheckModel struct {
+	ProjectID        types.String
+	UserPrompt       types.String
+	PriorUserPrompt  types.String
+	PriorInputParams types.List
+	LLMModelID       types.String
+	PriorLLMModelID  types.String
+	ImageRequired    types.Bool
+	OutputModality   types.String
+}
+
+// planInputParams keeps input_params while user_prompt is unchanged. A new
+// prompt leaves them unknown until the API has extracted them, as its grammar
+// is the one that counts.
+func (r *TaskResource) planInputParams(ctx context.Context, data taskPlanCheckModel, resp *resource.ModifyPlanResponse) {
+	if data.PriorInputParams.IsNull() || !data.UserPrompt.Equal(data.PriorUserPrompt) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("input_params"), data.PriorInputParams)...)
+}
+
+// checkModelCapabilities rejects plans that ask llm_model_id for something it
+// cannot do according to the model catalog, and warns about models that are
+// being retired.
+func (r *TaskResource) checkModelCapabilities(ctx context.Context, data taskPlanCheckModel, resp *resource.ModifyPlanResponse) {
+	// the provider may not be configured yet, e.g. when its own configuration
+	// depends on values that are unknown until apply.
+	if r.client == nil || data.LLMModelID.IsUnknown() || data.ProjectID.IsUnknown() {
+		return
+	}
+
+	// the catalog only makes the plan more helpful, so an outage should not
+	// stop it.
+	models, err := r.projectClient(data.ProjectID).GetAvailableLLMModels(ctx)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("llm_model_id"),
+			"Cannot check LLM model",
+			fmt.Sprintf("The model catalog could not be fetched, so llm_model_id was not checked: %s", err),
+		)
+		return
+	}
+
+	model := entitites.FindModelByID(models, data.LLMModelID.ValueString())
+
+	if model == nil {
+		// a model that has been removed from the catalog since the task was
+		// created should not block unrelated changes, only new references.
+		if data.LLMModelID.Equal(data.PriorLLMModelID) {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("llm_model_id"),
+				"LLM model no longer available",
+				fmt.Sprintf("The model %q is no longer in the model catalog and the Task may stop working. Choose a new llm_model_id.", data.LLMModelID.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("llm_model_id"),
+			"Unknown LLM model",
+			fmt.Sprintf("The model %q is not available in this project. Use the rightbrain_model data source to look up a valid model ID.", data.LLMModelID.ValueString()),
+		)
+		return
+	}
+
+	if model.IsDeprecated() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("llm_model_id"),
+			"LLM model is deprecated",
+			describeModelDeprecation(*model, models),
+		)
+	}
+
+	if data.ImageRequired.ValueBool() && !model.SupportsVision {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("image_required"),
+			"LLM model does not support vision",
+			fmt.Sprintf("The model %q (%s) cannot accept images, so image_required cannot be true.", model.Name, model.ID),
+		)
+	}
+
+	if data.OutputModality.ValueString() == "image" && model.SupportsImageOutput != nil && !*model.SupportsImageOutput {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("output_modality"),
+			"LLM model does not support image output",
+			fmt.Sprintf("The model %q (%s) cannot produce images, so output_modality cannot be \"image\".", model.Name, model.ID),
+		)
+	}
+}
+
+// describeModelDeprecation explains when a deprecated model goes away and what
+// to use instead.
+func describeModelDeprecation(model entitites.Model, models []entitites.Model) string {
+	msg := fmt.Sprintf("The model %q (%s) is deprecated", model.Name, model.ID)
+	if model.SunsetDate != nil {
+		msg += fmt.Sprintf(" and will be removed on %s", *model.SunsetDate)
+	}
+	msg += "."
+	if model.ReplacementModelID != nil {
+		replacement := entitites.FindModelByID(models, *model.ReplacementModelID)
+		if replacement != nil {
+			msg += fmt.Sprintf(" Use %q (%s) instead.", replacement.Name, replacement.ID)
+		} else {
+			msg += fmt.Sprintf(" Use %q instead.", *model.ReplacementModelID)
+		}
+	}
+	return msg
+}
+
 func (r *TaskResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -243,6 +643,13 @@ func (r *TaskResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+// projectClient returns a client for the project a task belongs to, which is
+// the project the provider is configured for unless project_id says
+// otherwise.
+func (r *TaskResource) projectClient(projectID types.String) *sdk.TasksClient {
+	return r.client.ForProject(projectID.ValueString())
+}
+
 func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data TaskResourceModel
 
@@ -262,15 +669,24 @@ func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, r
 	in.Enabled = data.Enabled.ValueBool()
 	in.Public = data.Public.ValueBool()
 	in.ImageRequired = data.ImageRequired.ValueBool()
+	in.OptimiseImages = data.OptimiseImages.ValueBool()
+	in.ExposedToAgents = data.ExposedToAgents.ValueBool()
 	in.OutputModality = data.OutputModality.ValueString()
 
-	for k, v := range data.OutputFormat {
-		in.OutputFormat[k] = v.ValueString()
+	outputFormat, err := r.FormatOutputFormat(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("output_format"), err.Error(), "")
+		return
 	}
+	in.OutputFormat = outputFormat
 
 	in.InputProcessors = r.FormatInputProcessors(data)
+	in.RAG = r.FormatRAG(data)
+	in.TaskForwarderID = data.TaskForwarderID.ValueStringPointer()
+
+	client := r.projectClient(data.ProjectID)
 
-	task, err := r.client.Create(ctx, in)
+	task, err := client.Create(ctx, in)
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
@@ -280,9 +696,11 @@ func (r *TaskResource) Create(ctx context.Context, req resource.CreateRequest, r
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
 	}
+	data.ProjectID = types.StringValue(client.ProjectID())
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newTaskIdentityModel(client, task))...)
 }
 
 func (r *TaskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -295,18 +713,48 @@ func (r *TaskResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	task, err := r.client.Fetch(ctx, sdk.NewFetchTaskRequest(data.ID.ValueString()))
+	client := r.projectClient(data.ProjectID)
+
+	task, err := client.Fetch(ctx, sdk.NewFetchTaskRequest(data.ID.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
 	}
 
+	identity := newTaskIdentityModel(client, task)
+
+	// state written before identity support has no identity to compare with
+	if req.Identity != nil && !req.Identity.Raw.IsNull() {
+		var prior TaskIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		switch {
+		case !prior.ID.Equal(identity.ID):
+			resp.Diagnostics.AddError(
+				"Task identity changed",
+				fmt.Sprintf("The task in state is %s but the API returned %s. The task was replaced outside of Terraform.", prior, identity),
+			)
+			return
+		case !prior.Equal(identity):
+			// the same task is reachable through a reconfigured provider, for
+			// example after an org or project was renamed or moved
+			resp.Diagnostics.AddWarning(
+				"Task identity changed",
+				fmt.Sprintf("The task in state is %s but the provider now reaches it as %s. The identity in state is updated to match the provider configuration.", prior, identity),
+			)
+		}
+	}
+
 	if err := data.PopulateFromTaskEntity(task); err != nil {
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
 	}
+	data.ProjectID = types.StringValue(client.ProjectID())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
 }
 
 func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -328,15 +776,24 @@ func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	in.Enabled = data.Enabled.ValueBool()
 	in.Public = data.Public.ValueBool()
 	in.ImageRequired = data.ImageRequired.ValueBool()
+	in.OptimiseImages = data.OptimiseImages.ValueBool()
+	in.ExposedToAgents = data.ExposedToAgents.ValueBool()
 	in.OutputModality = data.OutputModality.ValueString()
 
-	for k, v := range data.OutputFormat {
-		in.OutputFormat[k] = v.ValueString()
+	outputFormat, err := r.FormatOutputFormat(data)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("output_format"), err.Error(), "")
+		return
 	}
+	in.OutputFormat = outputFormat
 
 	in.InputProcessors = r.FormatInputProcessors(data)
+	in.RAG = r.FormatRAG(data)
+	in.TaskForwarderID = data.TaskForwarderID.ValueStringPointer()
+
+	client := r.projectClient(data.ProjectID)
 
-	task, err := r.client.Update(ctx, in)
+	task, err := client.Update(ctx, in)
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
@@ -346,9 +803,11 @@ func (r *TaskResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
 	}
+	data.ProjectID = types.StringValue(client.ProjectID())
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, newTaskIdentityModel(client, task))...)
 }
 
 func (r *TaskResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -361,15 +820,77 @@ func (r *TaskResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.Delete(ctx, sdk.NewDeleteTaskRequest(data.ID.ValueString()))
+	err := r.projectClient(data.ProjectID).Delete(ctx, sdk.NewDeleteTaskRequest(data.ID.ValueString()))
 	if err != nil {
 		resp.Diagnostics.AddError(err.Error(), "")
 		return
 	}
 }
 
+// ImportState accepts a task ID, a `project_id/task_id` pair or a
+// `name:<task name>` reference, or an identity.
 func (r *TaskResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" {
+		r.importStateByIdentity(ctx, req, resp)
+		return
+	}
+
+	projectID, id, err := resolveTaskImportID(ctx, r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+}
+
+// importStateByIdentity imports the task named by the identity in an import
+// block, which must belong to the org the provider is configured for.
+func (r *TaskResource) importStateByIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity TaskIdentityModel
+
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !identity.OrgID.IsNull() && identity.OrgID.ValueString() != r.client.OrgID() {
+		resp.Diagnostics.AddError(fmt.Sprintf("cannot import task %q from org %q, the provider is configured for org %q; use a provider configured with that org", identity.ID.ValueString(), identity.OrgID.ValueString(), r.client.OrgID()), "")
+		return
+	}
+
+	identity.OrgID = types.StringValue(r.client.OrgID())
+	if identity.ProjectID.IsNull() {
+		identity.ProjectID = types.StringValue(r.client.ProjectID())
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), identity.ProjectID)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
+}
+
+// resolveTaskImportID turns an import ID into the project and ID of a task.
+// Only a `project_id/task_id` pair names a project other than the one the
+// client is configured for.
+func resolveTaskImportID(ctx context.Context, client *sdk.TasksClient, importID string) (string, string, error) {
+	if name, ok := strings.CutPrefix(importID, "name:"); ok {
+		if name == "" {
+			return "", "", fmt.Errorf("cannot import task, %q does not include a name", importID)
+		}
+		id, err := findTaskIDByName(ctx, client, name)
+		return client.ProjectID(), id, err
+	}
+
+	if projectID, taskID, ok := strings.Cut(importID, "/"); ok {
+		if projectID == "" || taskID == "" || strings.Contains(taskID, "/") {
+			return "", "", fmt.Errorf("cannot import task, expected project_id/task_id but got %q", importID)
+		}
+		return projectID, taskID, nil
+	}
+
+	return client.ProjectID(), importID, nil
 }
 
 func (r *TaskResource) FormatInputProcessors(data TaskResourceModel) *[]entitites.InputProcessor {
@@ -392,3 +913,21 @@ func (r *TaskResource) FormatInputProcessors(data TaskResourceModel) *[]entitite
 	}
 	return &ips
 }
+
+func (r *TaskResource) FormatOutputFormat(data TaskResourceModel) (entitites.OutputFormat, error) {
+	values := make(map[string]string, len(data.OutputFormat))
+	for k, v := range data.OutputFormat {
+		values[k] = v.ValueString()
+	}
+	return entitites.NewOutputFormat(values)
+}
+
+func (r *TaskResource) FormatRAG(data TaskResourceModel) *entitites.RAG {
+	if data.RAG == nil {
+		return nil
+	}
+	return &entitites.RAG{
+		CollectionID: data.RAG.CollectionID.ValueString(),
+		RAGParam:     data.RAG.RAGParam.ValueString(),
+	}
+}
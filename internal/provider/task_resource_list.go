@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ list.ListResource = &TaskResource{}
+var _ list.ListResourceWithConfigure = &TaskResource{}
+
+func NewTaskListResource() list.ListResource {
+	return &TaskResource{}
+}
+
+// TaskListConfigModel describes the list block data model.
+type TaskListConfigModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *TaskResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Tasks in the project.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list Tasks whose name starts with this value.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list Tasks whose enabled flag matches this value.",
+			},
+		},
+	}
+}
+
+func (r *TaskResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config TaskListConfigModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	prefix := config.NamePrefix.ValueString()
+
+	in := sdk.NewListTasksRequest()
+	in.Name = prefix
+	if !config.Enabled.IsNull() {
+		enabled := config.Enabled.ValueBool()
+		in.Enabled = &enabled
+	}
+
+	tasks := r.client.FetchAllTasks(ctx, in)
+	if !req.IncludeResource {
+		tasks = func(yield func(*entitites.Task, error) bool) {
+			for task, err := range r.client.AllTasks(ctx, in) {
+				if !yield(&task, err) {
+					return
+				}
+			}
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+
+		for task, err := range tasks {
+			if err != nil {
+				push(list.ListResult{Diagnostics: diag.Diagnostics{diag.NewErrorDiagnostic(err.Error(), "")}})
+				return
+			}
+			// the API matches names anywhere, not just at the start
+			if !strings.HasPrefix(task.Name, prefix) {
+				continue
+			}
+			if req.Limit > 0 && count == req.Limit {
+				return
+			}
+			count++
+			if !push(r.newTaskListResult(ctx, req, task)) {
+				return
+			}
+		}
+	}
+}
+
+func (r *TaskResource) newTaskListResult(ctx context.Context, req list.ListRequest, task *entitites.Task) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = task.Name
+
+	result.Diagnostics.Append(result.Identity.Set(ctx, newTaskIdentityModel(r.client, task))...)
+
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	var data TaskResourceModel
+	if err := data.PopulateFromTaskEntity(task); err != nil {
+		result.Diagnostics.AddError(err.Error(), "")
+		return result
+	}
+	data.ProjectID = types.StringValue(r.client.ProjectID())
+
+	result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+
+	return result
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskListResource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	joke := api.addTask("Joke")
+	api.addTask("Joke of the day").Enabled = false
+	api.addTask("Practical Joke")
+	r := newTestTaskResource(t, api)
+
+	t.Run("test that it lists tasks by name prefix", func(t *testing.T) {
+		results := listTestTasks(t, r, TaskListConfigModel{NamePrefix: types.StringValue("Joke")}, 0, false)
+		// the API returns "Practical Joke" too, so the prefix is checked again
+		assert.Equal(t, "Joke", api.lastQuery().Get("name"))
+		assert.Len(t, results, 2)
+		assert.Equal(t, "Joke", results[0].DisplayName)
+		assert.Equal(t, "Joke of the day", results[1].DisplayName)
+
+		var identity TaskIdentityModel
+		assert.False(t, results[0].Identity.Get(context.Background(), &identity).HasError())
+		assert.Equal(t, TaskIdentityModel{
+			OrgID:     types.StringValue(fakeOrgID),
+			ProjectID: types.StringValue(fakeProjectID),
+			ID:        types.StringValue(joke.ID),
+		}, identity)
+	})
+
+	t.Run("test that filters and limits are combined", func(t *testing.T) {
+		results := listTestTasks(t, r, TaskListConfigModel{Enabled: types.BoolValue(true)}, 0, false)
+		assert.Len(t, results, 2)
+
+		results = listTestTasks(t, r, TaskListConfigModel{}, 1, false)
+		assert.Len(t, results, 1)
+	})
+
+	t.Run("test that it includes the resource when asked", func(t *testing.T) {
+		results := listTestTasks(t, r, TaskListConfigModel{NamePrefix: types.StringValue("Joke"), Enabled: types.BoolValue(true)}, 0, true)
+		assert.Len(t, results, 1)
+
+		var data TaskResourceModel
+		assert.False(t, results[0].Resource.Get(context.Background(), &data).HasError())
+		assert.Equal(t, types.StringValue(joke.ID), data.ID)
+		assert.Equal(t, types.StringValue("Tell me about {subject}"), data.UserPrompt)
+
+		var want TaskResourceModel
+		assert.NoError(t, want.PopulateFromTaskEntity(joke))
+		want.ProjectID = types.StringValue(fakeProjectID)
+		assert.Equal(t, want, data)
+	})
+}
+
+func listTestTasks(t *testing.T, r *TaskResource, config TaskListConfigModel, limit int64, includeResource bool) []list.ListResult {
+	ctx := context.Background()
+
+	s := &list.ListResourceSchemaResponse{}
+	r.ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, s)
+
+	raw := tfsdk.State{Schema: s.Schema, Raw: tftypes.NewValue(s.Schema.Type().TerraformType(ctx), nil)}
+	assert.False(t, raw.Set(ctx, &config).HasError())
+
+	stream := &list.ListResultsStream{}
+	r.List(ctx, list.ListRequest{
+		Config:                 tfsdk.Config{Schema: s.Schema, Raw: raw.Raw},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         getTestResourceSchema(t, r).Schema,
+		ResourceIdentitySchema: newTestResourceIdentity(t, r).Schema,
+	}, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		assert.False(t, result.Diagnostics.HasError(), result.Diagnostics)
+		results = append(results, result)
+	}
+	return results
+}
@@ -0,0 +1,692 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"terraform-provider-tasks/internal/export"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func TestTaskResource(t *testing.T) {
+
+	t.Run("test that create sends every revision setting and round-trips", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+
+		plan := newTestTaskResourceModel()
+		plan.OptimiseImages = types.BoolValue(false)
+		plan.ExposedToAgents = types.BoolValue(true)
+
+		state, diags := createTestResource(t, r, plan)
+		assert.False(t, diags.HasError(), diags)
+
+		body := api.lastBody()
+		assert.Equal(t, false, body["optimise_images"])
+		assert.Equal(t, true, body["exposed_to_agents"])
+		assert.Equal(t, "image", body["output_modality"])
+
+		assert.Equal(t, plan.OptimiseImages, state.OptimiseImages)
+		assert.Equal(t, plan.ExposedToAgents, state.ExposedToAgents)
+		assert.Equal(t, plan.OutputModality, state.OutputModality)
+		assert.Equal(t, plan.ImageRequired, state.ImageRequired)
+		assert.Equal(t, plan.UserPrompt, state.UserPrompt)
+	})
+
+	t.Run("test that update sends every revision setting and round-trips", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+
+		prior, diags := createTestResource(t, r, newTestTaskResourceModel())
+		assert.False(t, diags.HasError(), diags)
+
+		plan := prior
+		plan.OptimiseImages = types.BoolValue(false)
+		plan.ExposedToAgents = types.BoolValue(true)
+		plan.UserPrompt = types.StringValue("Tell me a joke about {subject} in {language}")
+
+		state, diags := updateTestResource(t, r, prior, plan)
+		assert.False(t, diags.HasError(), diags)
+
+		body := api.lastBody()
+		assert.Equal(t, false, body["optimise_images"])
+		assert.Equal(t, true, body["exposed_to_agents"])
+
+		assert.Equal(t, plan.OptimiseImages, state.OptimiseImages)
+		assert.Equal(t, plan.ExposedToAgents, state.ExposedToAgents)
+		assert.Equal(t, plan.UserPrompt, state.UserPrompt)
+		assert.NotEqual(t, prior.ActiveRevisionID, state.ActiveRevisionID)
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("subject"), types.StringValue("language")}), state.InputParams)
+	})
+
+	t.Run("test that the rag block round-trips", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+
+		plan := newTestTaskResourceModel()
+		plan.UserPrompt = types.StringValue("Tell me a joke about {subject} using {context}")
+		plan.RAG = &RAGModel{
+			CollectionID: types.StringValue("019010a2-8327-2607-11d7-41bb0a8936d5"),
+			RAGParam:     types.StringValue("context"),
+		}
+
+		state, diags := createTestResource(t, r, plan)
+		assert.False(t, diags.HasError(), diags)
+
+		assert.Equal(t, map[string]any{"collection_id": "019010a2-8327-2607-11d7-41bb0a8936d5", "rag_param": "context"}, api.lastBody()["rag"])
+		assert.Equal(t, plan.RAG, state.RAG)
+
+		rev, err := api.tasks[state.ID.ValueString()].GetActiveRevision()
+		assert.NoError(t, err)
+		assert.True(t, rev.HasRAG())
+	})
+
+	t.Run("test that an absent rag block stays absent", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+
+		state, diags := createTestResource(t, r, newTestTaskResourceModel())
+		assert.False(t, diags.HasError(), diags)
+
+		assert.Nil(t, api.lastBody()["rag"])
+		assert.Nil(t, state.RAG)
+
+		rev, err := api.tasks[state.ID.ValueString()].GetActiveRevision()
+		assert.NoError(t, err)
+		assert.False(t, rev.HasRAG())
+	})
+
+	t.Run("test that exported configuration imports with an empty plan", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		api.models = []entitites.Model{{ID: "019010a2-8327-2607-11d7-41bb0a8936d3", Name: "gpt-4o-mini", SupportsVision: true}}
+		server := newTestProviderServer(t, api)
+
+		plan := newTestTaskResourceModel()
+		plan.OutputFormat = map[string]types.String{
+			"joke":   types.StringValue("str"),
+			"rating": types.StringValue(`{"type": "int", "description": "How funny the joke is"}`),
+			"tags":   types.StringValue(`{"type":"list","item_type":"str"}`),
+		}
+		task, diags := createTestResource(t, newTestTaskResource(t, api), plan)
+		assert.False(t, diags.HasError(), diags)
+
+		var exported bytes.Buffer
+		assert.NoError(t, export.WriteTasks(&exported, []entitites.Task{*api.tasks[task.ID.ValueString()]}))
+		importID, config := parseTestExportedTask(t, exported.Bytes())
+
+		prior, identity := importTestTaskResourceState(t, server, importID)
+		planned := planTestTaskResourceChange(t, server, prior, identity, config)
+		assert.True(t, prior.Equal(planned), "planned %s\nprior %s", planned, prior)
+	})
+
+	t.Run("test that output_format objects round-trip", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+
+		plan := newTestTaskResourceModel()
+		plan.OutputFormat = map[string]types.String{
+			"joke":   types.StringValue("str"),
+			"rating": types.StringValue(`{"type": "int", "description": "How funny the joke is"}`),
+			"tags":   types.StringValue(`{"type":"list","item_type":"str"}`),
+		}
+		state, diags := createTestResource(t, r, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, map[string]any{
+			"joke":   "str",
+			"rating": map[string]any{"type": "int", "description": "How funny the joke is"},
+			"tags":   map[string]any{"type": "list", "item_type": "str"},
+		}, api.lastBody()["output_format"])
+		assert.Equal(t, plan.OutputFormat, state.OutputFormat)
+
+		// without a prior value, as after an import, objects are encoded
+		// with sorted keys
+		var imported TaskResourceModel
+		assert.NoError(t, imported.PopulateFromTaskEntity(api.tasks[state.ID.ValueString()]))
+		assert.Equal(t, map[string]types.String{
+			"joke":   types.StringValue("str"),
+			"rating": types.StringValue(`{"description":"How funny the joke is","type":"int"}`),
+			"tags":   types.StringValue(`{"item_type":"str","type":"list"}`),
+		}, imported.OutputFormat)
+	})
+
+	t.Run("test that input_params are kept until the user prompt changes", func(t *testing.T) {
+		r := &TaskResource{}
+
+		prior := newTestTaskResourceModel()
+		prior.ID = types.StringValue("019011e6-e530-3aca-6cf7-2973387c255d")
+		prior.InputParams = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("subject")})
+
+		plan := newTestTaskResourceModel()
+		plan.ID = prior.ID
+		plan.Description = types.StringValue("Tells a better joke")
+		planned, diags := modifyTestResourcePlan(t, r, &prior, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, prior.InputParams, planned.InputParams)
+
+		plan.UserPrompt = types.StringValue("Tell me a joke about {subject} for {audience}")
+		planned, diags = modifyTestResourcePlan(t, r, &prior, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.True(t, planned.InputParams.IsUnknown())
+
+		planned, diags = modifyTestResourcePlan(t, r, nil, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.True(t, planned.InputParams.IsUnknown())
+	})
+
+	t.Run("test that read takes input_params from the API", func(t *testing.T) {
+		var state TaskResourceModel
+		assert.NoError(t, state.PopulateFromTaskEntity(&entitites.Task{Revisions: []entitites.Revision{{
+			Active:      true,
+			UserPrompt:  "Tell me a joke about {subject} in {language}",
+			InputParams: []string{"language", "subject", "context"},
+		}}}))
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("language"), types.StringValue("subject"), types.StringValue("context")}), state.InputParams)
+	})
+
+	t.Run("test that config validation reports prompt and parameter mistakes", func(t *testing.T) {
+		r := &TaskResource{}
+
+		config := newTestTaskResourceModel()
+		config.UserPrompt = types.StringValue("Summarise {document} for {audience}")
+		config.RAG = &RAGModel{CollectionID: types.StringValue("collection"), RAGParam: types.StringValue("context")}
+		config.InputProcessors = &InputProcessorsModel{InputProcessors: []InputProcessorModel{
+			{ParamName: types.StringValue("document"), InputProcessor: types.StringValue("url_fetcher")},
+			{ParamName: types.StringValue("document"), InputProcessor: types.StringValue("url_fetcher")},
+			{ParamName: types.StringValue("unknown"), InputProcessor: types.StringValue("url_fetcher")},
+		}}
+
+		diags := validateTestTaskConfig(t, r, config)
+
+		assert.Equal(t, 1, diags.ErrorsCount(), diags)
+		assert.Equal(t, 2, diags.WarningsCount(), diags)
+		assert.True(t, diags.Contains(diag.NewAttributeWarningDiagnostic(
+			path.Root("rag").AtName("rag_param"),
+			"RAG parameter not used in prompt",
+			`The rag_param "context" must appear as a {context} placeholder in user_prompt.`,
+		)))
+		assert.True(t, diags.Contains(diag.NewAttributeErrorDiagnostic(
+			path.Root("input_processors").AtName("input_processor").AtListIndex(1).AtName("param_name"),
+			"Duplicate input processor parameter",
+			`More than one input_processor is declared for param_name "document".`,
+		)))
+		assert.True(t, diags.Contains(diag.NewAttributeWarningDiagnostic(
+			path.Root("input_processors").AtName("input_processor").AtListIndex(2).AtName("param_name"),
+			"Input processor parameter not used in prompt",
+			`The param_name "unknown" must appear as a {unknown} placeholder in user_prompt.`,
+		)))
+	})
+
+	t.Run("test that config validation warns about malformed braces", func(t *testing.T) {
+		r := &TaskResource{}
+
+		for _, prompt := range []string{"Tell me a joke about {subject", "Tell me a joke about subject}", "Tell me a joke about {the subject}"} {
+			config := newTestTaskResourceModel()
+			config.UserPrompt = types.StringValue(prompt)
+
+			diags := validateTestTaskConfig(t, r, config)
+
+			assert.False(t, diags.HasError(), diags)
+			assert.Equal(t, 1, diags.WarningsCount(), diags)
+			assert.Equal(t, path.Root("user_prompt"), diags[0].(diag.DiagnosticWithPath).Path()) //nolint:forcetypeassert
+		}
+	})
+
+	t.Run("test that the plan is checked against the model catalog", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		api.models = []entitites.Model{
+			{ID: "019010a2-8327-2607-11d7-41bb0a8936d3", Name: "gpt-4o-mini", SupportsVision: false, SupportsImageOutput: new(bool)},
+		}
+		r := newTestTaskResource(t, api)
+
+		plan := newTestTaskResourceModel()
+		_, diags := modifyTestResourcePlan(t, r, nil, plan)
+		assert.Equal(t, 2, diags.ErrorsCount(), diags)
+		assert.Equal(t, "LLM model does not support vision", diags[0].Summary())
+		assert.Equal(t, "LLM model does not support image output", diags[1].Summary())
+
+		plan.LLMModelID = types.StringValue("does-not-exist")
+		_, diags = modifyTestResourcePlan(t, r, nil, plan)
+		assert.Equal(t, 1, diags.ErrorsCount(), diags)
+		assert.Equal(t, "Unknown LLM model", diags[0].Summary())
+	})
+
+	t.Run("test that the catalog check is skipped when the catalog is unavailable", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+		api.server.Close()
+
+		_, diags := modifyTestResourcePlan(t, r, nil, newTestTaskResourceModel())
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Cannot check LLM model", diags[0].Summary())
+	})
+
+	t.Run("test that retiring models produce warnings", func(t *testing.T) {
+		deprecated, sunsetDate, replacementModelID := true, "2026-12-31", "new"
+		api := newFakeTasksAPI(t)
+		api.models = []entitites.Model{
+			{ID: "old", Name: "gpt-4", SupportsVision: true, Deprecated: &deprecated, SunsetDate: &sunsetDate, ReplacementModelID: &replacementModelID},
+			{ID: "new", Name: "gpt-4o", SupportsVision: true},
+		}
+		r := newTestTaskResource(t, api)
+
+		plan := newTestTaskResourceModel()
+		plan.OutputModality = types.StringValue("json")
+		plan.LLMModelID = types.StringValue("old")
+		_, diags := modifyTestResourcePlan(t, r, nil, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, `The model "gpt-4" (old) is deprecated and will be removed on 2026-12-31. Use "gpt-4o" (new) instead.`, diags[0].Detail())
+
+		prior := plan
+		prior.LLMModelID = types.StringValue("removed")
+		plan.LLMModelID = types.StringValue("removed")
+		_, diags = modifyTestResourcePlan(t, r, &prior, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "LLM model no longer available", diags[0].Summary())
+	})
+
+	t.Run("test that tasks are imported by id, name or project and id", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		joke := api.addTask("Joke")
+		api.addTask("Joke of the day")
+		riddle := api.addTask("Riddle")
+		riddle.ProjectID = fakeOtherProjectID
+		r := newTestTaskResource(t, api)
+
+		imported, diags := importTestTask(t, r, joke.ID)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(joke.ID), imported.ID)
+		assert.Equal(t, types.StringValue(fakeProjectID), imported.ProjectID)
+
+		imported, diags = importTestTask(t, r, "name:Joke")
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(joke.ID), imported.ID)
+		assert.Equal(t, types.StringValue(fakeProjectID), imported.ProjectID)
+
+		imported, diags = importTestTask(t, r, fakeOtherProjectID+"/"+riddle.ID)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(riddle.ID), imported.ID)
+		assert.Equal(t, types.StringValue(fakeOtherProjectID), imported.ProjectID)
+
+		identity, diags := readTestTaskResource(t, r, imported, nil)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(fakeOtherProjectID), identity.ProjectID)
+	})
+
+	t.Run("test that tasks are created in the configured project", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		api.models = []entitites.Model{{ID: "019010a2-8327-2607-11d7-41bb0a8936d3", Name: "gpt-4o-mini", SupportsVision: true}}
+		r := newTestTaskResource(t, api)
+
+		plan := newTestTaskResourceModel()
+		planned, diags := modifyTestResourcePlan(t, r, nil, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(fakeProjectID), planned.ProjectID)
+
+		plan.ProjectID = types.StringValue(fakeOtherProjectID)
+		planned, diags = modifyTestResourcePlan(t, r, nil, plan)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(fakeOtherProjectID), planned.ProjectID)
+
+		state, diags := createTestResource(t, r, planned)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, fakeOtherProjectID, api.tasks[state.ID.ValueString()].ProjectID)
+		assert.Equal(t, types.StringValue(fakeOtherProjectID), state.ProjectID)
+	})
+
+	t.Run("test that ambiguous or foreign imports are an error", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		first := api.addTask("Joke")
+		second := api.addTask("Joke")
+		r := newTestTaskResource(t, api)
+
+		_, diags := importTestTask(t, r, "name:Joke")
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, `more than one task is named "Joke", use one of the IDs instead: `+first.ID+", "+second.ID, diags[0].Summary())
+
+		_, diags = importTestTask(t, r, "name:Riddle")
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, `cannot find task named "Riddle"`, diags[0].Summary())
+
+		for _, importID := range []string{"/" + first.ID, fakeProjectID + "/", fakeProjectID + "/" + first.ID + "/run"} {
+			_, diags = importTestTask(t, r, importID)
+			assert.Equal(t, 1, diags.ErrorsCount())
+			assert.Equal(t, `cannot import task, expected project_id/task_id but got "`+importID+`"`, diags[0].Summary())
+		}
+	})
+
+	t.Run("test that tasks are imported by identity", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		joke := api.addTask("Joke")
+		r := newTestTaskResource(t, api)
+
+		id, identity, diags := importTestTaskByIdentity(t, r, TaskIdentityModel{
+			OrgID:     types.StringNull(),
+			ProjectID: types.StringNull(),
+			ID:        types.StringValue(joke.ID),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, joke.ID, id)
+		assert.Equal(t, TaskIdentityModel{
+			OrgID:     types.StringValue(fakeOrgID),
+			ProjectID: types.StringValue(fakeProjectID),
+			ID:        types.StringValue(joke.ID),
+		}, identity)
+
+		_, _, diags = importTestTaskByIdentity(t, r, TaskIdentityModel{
+			OrgID:     types.StringValue("another-org"),
+			ProjectID: types.StringNull(),
+			ID:        types.StringValue(joke.ID),
+		})
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Contains(t, diags[0].Summary(), `from org "another-org"`)
+
+		other := TaskIdentityModel{
+			OrgID:     types.StringValue(fakeOrgID),
+			ProjectID: types.StringValue(fakeOtherProjectID),
+			ID:        types.StringValue(joke.ID),
+		}
+		_, identity, diags = importTestTaskByIdentity(t, r, other)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, other, identity)
+	})
+
+	t.Run("test that read detects a changed identity", func(t *testing.T) {
+		api := newFakeTasksAPI(t)
+		r := newTestTaskResource(t, api)
+		state, diags := createTestResource(t, r, newTestTaskResourceModel())
+		assert.False(t, diags.HasError(), diags)
+
+		identity := TaskIdentityModel{
+			OrgID:     types.StringValue(fakeOrgID),
+			ProjectID: types.StringValue(fakeProjectID),
+			ID:        state.ID,
+		}
+		read, diags := readTestTaskResource(t, r, state, &identity)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, identity, read)
+
+		_, diags = readTestTaskResource(t, r, state, nil)
+		assert.False(t, diags.HasError(), diags)
+
+		moved := identity
+		moved.OrgID = types.StringValue("another-org")
+		read, diags = readTestTaskResource(t, r, state, &moved)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, 1, diags.WarningsCount())
+		assert.Equal(t, "Task identity changed", diags[0].Summary())
+		assert.Equal(t, identity, read)
+
+		api.models = []entitites.Model{{ID: "019010a2-8327-2607-11d7-41bb0a8936d3", Name: "gpt-4o-mini", SupportsVision: true}}
+		server := newTestProviderServer(t, api)
+		prior, current := importTestTaskResourceState(t, server, state.ID.ValueString())
+		current.IdentityData = setTestIdentityAttribute(t, r, current.IdentityData, "org_id", "another-org")
+		priorState, err := tfprotov6.NewDynamicValue(getTestTaskResourceType(t), prior)
+		assert.NoError(t, err)
+		refreshed, err := server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+			TypeName:        "rightbrain_task",
+			CurrentState:    &priorState,
+			CurrentIdentity: current,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, refreshed.Diagnostics, 1)
+		assert.Equal(t, tfprotov6.DiagnosticSeverityWarning, refreshed.Diagnostics[0].Severity)
+
+		replaced := identity
+		replaced.ID = types.StringValue("019011e6-e530-3aca-6cf7-2973387c255d")
+		_, diags = readTestTaskResource(t, r, state, &replaced)
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "Task identity changed", diags[0].Summary())
+	})
+}
+
+func newTestTaskResource(t *testing.T, api *fakeTasksAPI) *TaskResource {
+	r := &TaskResource{}
+	resp := &resource.ConfigureResponse{}
+	r.Configure(context.Background(), resource.ConfigureRequest{ProviderData: api.client()}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return r
+}
+
+func newTestTaskResourceModel() TaskResourceModel {
+	return TaskResourceModel{
+		ID:               types.StringUnknown(),
+		ProjectID:        types.StringUnknown(),
+		Name:             types.StringValue("Tell me a Joke!"),
+		Description:      types.StringValue("Tells a joke about a given subject"),
+		Enabled:          types.BoolValue(true),
+		Public:           types.BoolValue(false),
+		ExposedToAgents:  types.BoolValue(false),
+		SystemPrompt:     types.StringValue("You can tell good jokes about anything"),
+		UserPrompt:       types.StringValue("Tell me a joke about {subject}"),
+		LLMModelID:       types.StringValue("019010a2-8327-2607-11d7-41bb0a8936d3"),
+		ImageRequired:    types.BoolValue(true),
+		OutputFormat:     map[string]types.String{"joke": types.StringValue("str")},
+		OutputModality:   types.StringValue("image"),
+		OptimiseImages:   types.BoolValue(true),
+		TaskForwarderID:  types.StringNull(),
+		InputParams:      types.ListUnknown(types.StringType),
+		ActiveRevisionID: types.StringUnknown(),
+		CreatedAt:        types.StringUnknown(),
+		UpdatedAt:        types.StringUnknown(),
+	}
+}
+
+func getTestResourceSchema(t *testing.T, r resource.Resource) *resource.SchemaResponse {
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+	assert.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+	return resp
+}
+
+func importTestTask(t *testing.T, r *TaskResource, id string) (TaskResourceModel, diag.Diagnostics) {
+	ctx := context.Background()
+	s := getTestResourceSchema(t, r)
+
+	resp := &resource.ImportStateResponse{
+		State:    tfsdk.State{Schema: s.Schema, Raw: tftypes.NewValue(s.Schema.Type().TerraformType(ctx), nil)},
+		Identity: newTestResourceIdentity(t, r),
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id, Identity: newTestResourceIdentity(t, r)}, resp)
+
+	var imported TaskResourceModel
+	if !resp.State.Raw.IsNull() {
+		assert.False(t, resp.State.Get(ctx, &imported).HasError())
+	}
+	return imported, resp.Diagnostics
+}
+
+func importTestTaskByIdentity(t *testing.T, r *TaskResource, identity TaskIdentityModel) (string, TaskIdentityModel, diag.Diagnostics) {
+	ctx := context.Background()
+	s := getTestResourceSchema(t, r)
+
+	req := resource.ImportStateRequest{Identity: newTestResourceIdentity(t, r)}
+	assert.False(t, req.Identity.Set(ctx, identity).HasError())
+
+	resp := &resource.ImportStateResponse{
+		State:    tfsdk.State{Schema: s.Schema, Raw: tftypes.NewValue(s.Schema.Type().TerraformType(ctx), nil)},
+		Identity: &tfsdk.ResourceIdentity{Schema: req.Identity.Schema, Raw: req.Identity.Raw.Copy()},
+	}
+	r.ImportState(ctx, req, resp)
+
+	var imported types.String
+	assert.False(t, resp.State.GetAttribute(ctx, path.Root("id"), &imported).HasError())
+	assert.False(t, resp.Identity.Get(ctx, &identity).HasError())
+	return imported.ValueString(), identity, resp.Diagnostics
+}
+
+// readTestTaskResource reads the task in state, with prior identity when it
+// is not nil, and returns the identity after the read.
+func readTestTaskResource(t *testing.T, r *TaskResource, state TaskResourceModel, prior *TaskIdentityModel) (TaskIdentityModel, diag.Diagnostics) {
+	ctx := context.Background()
+	s := getTestResourceSchema(t, r)
+
+	req := resource.ReadRequest{
+		State:    tfsdk.State{Schema: s.Schema, Raw: tftypes.NewValue(s.Schema.Type().TerraformType(ctx), nil)},
+		Identity: newTestResourceIdentity(t, r),
+	}
+	assert.False(t, req.State.Set(ctx, &state).HasError())
+	if prior != nil {
+		assert.False(t, req.Identity.Set(ctx, prior).HasError())
+	}
+
+	resp := &resource.ReadResponse{State: req.State, Identity: newTestResourceIdentity(t, r)}
+	r.Read(ctx, req, resp)
+
+	var identity TaskIdentityModel
+	if !resp.Identity.Raw.IsNull() {
+		assert.False(t, resp.Identity.Get(ctx, &identity).HasError())
+	}
+	return identity, resp.Diagnostics
+}
+
+func validateTestTaskConfig(t *testing.T, r *TaskResource, data TaskResourceModel) diag.Diagnostics {
+	ctx := context.Background()
+	s := getTestResourceSchema(t, r)
+
+	// configuration never holds values for computed only attributes
+	data.ID = types.StringNull()
+	data.InputParams = types.ListNull(types.StringType)
+	data.ActiveRevisionID = types.StringNull()
+	data.CreatedAt = types.StringNull()
+	data.UpdatedAt = types.StringNull()
+
+	plan := tfsdk.Plan{Schema: s.Schema, Raw: tftypes.NewValue(s.Schema.Type().TerraformType(ctx), nil)}
+	assert.False(t, plan.Set(ctx, &data).HasError())
+
+	resp := &resource.ValidateConfigResponse{}
+	r.ValidateConfig(ctx, resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s.Schema, Raw: plan.Raw}}, resp)
+	return resp.Diagnostics
+}
+
+// parseTestExportedTask returns the import ID and the configuration of the
+// single task written by export.WriteTasks.
+func parseTestExportedTask(t *testing.T, src []byte) (string, tftypes.Value) {
+	file, diags := hclsyntax.ParseConfig(src, "tasks.tf", hcl.InitialPos)
+	assert.False(t, diags.HasErrors(), diags.Error())
+
+	var importID string
+	config := make(map[string]json.RawMessage)
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		for name, attr := range block.Body.Attributes {
+			if block.Type == "import" && name == "id" {
+				value, diags := attr.Expr.Value(nil)
+				assert.False(t, diags.HasErrors(), diags.Error())
+				importID = value.AsString()
+			}
+			if block.Type == "resource" {
+				value, diags := attr.Expr.Value(nil)
+				assert.False(t, diags.HasErrors(), diags.Error())
+				data, err := ctyjson.Marshal(value, value.Type())
+				assert.NoError(t, err)
+				config[name] = data
+			}
+		}
+	}
+
+	data, err := json.Marshal(config)
+	assert.NoError(t, err)
+	value, err := (&tfprotov6.DynamicValue{JSON: data}).Unmarshal(getTestTaskResourceType(t))
+	assert.NoError(t, err)
+	return importID, value
+}
+
+// importTestTaskResourceState imports the task and refreshes it, as
+// Terraform does for an import block.
+func importTestTaskResourceState(t *testing.T, server tfprotov6.ProviderServer, importID string) (tftypes.Value, *tfprotov6.ResourceIdentityData) {
+	ctx := context.Background()
+
+	imported, err := server.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{TypeName: "rightbrain_task", ID: importID})
+	assert.NoError(t, err)
+	assert.Empty(t, imported.Diagnostics)
+
+	read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:        "rightbrain_task",
+		CurrentState:    imported.ImportedResources[0].State,
+		CurrentIdentity: imported.ImportedResources[0].Identity,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, read.Diagnostics)
+
+	state, err := read.NewState.Unmarshal(getTestTaskResourceType(t))
+	assert.NoError(t, err)
+	return state, read.NewIdentity
+}
+
+// planTestTaskResourceChange plans config against prior, proposing the prior
+// value of every attribute the configuration leaves null as Terraform does.
+func planTestTaskResourceChange(t *testing.T, server tfprotov6.ProviderServer, prior tftypes.Value, identity *tfprotov6.ResourceIdentityData, config tftypes.Value) tftypes.Value {
+	ctx := context.Background()
+	typ := getTestTaskResourceType(t)
+
+	var priorAttrs, proposedAttrs map[string]tftypes.Value
+	assert.NoError(t, prior.As(&priorAttrs))
+	assert.NoError(t, config.As(&proposedAttrs))
+	for name, value := range proposedAttrs {
+		if value.IsNull() {
+			proposedAttrs[name] = priorAttrs[name]
+		}
+	}
+
+	priorState, err := tfprotov6.NewDynamicValue(typ, prior)
+	assert.NoError(t, err)
+	proposed, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, proposedAttrs))
+	assert.NoError(t, err)
+	configValue, err := tfprotov6.NewDynamicValue(typ, config)
+	assert.NoError(t, err)
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "rightbrain_task",
+		PriorState:       &priorState,
+		ProposedNewState: &proposed,
+		Config:           &configValue,
+		PriorIdentity:    identity,
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+	assert.Empty(t, resp.RequiresReplace)
+
+	planned, err := resp.PlannedState.Unmarshal(typ)
+	assert.NoError(t, err)
+	return planned
+}
+
+// setTestIdentityAttribute returns identity with the string attribute name
+// set to value.
+func setTestIdentityAttribute(t *testing.T, r *TaskResource, identity *tfprotov6.DynamicValue, name string, value string) *tfprotov6.DynamicValue {
+	typ := newTestResourceIdentity(t, r).Schema.Type().TerraformType(context.Background())
+
+	raw, err := identity.Unmarshal(typ)
+	assert.NoError(t, err)
+	var attrs map[string]tftypes.Value
+	assert.NoError(t, raw.As(&attrs))
+	attrs[name] = tftypes.NewValue(tftypes.String, value)
+
+	result, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, attrs))
+	assert.NoError(t, err)
+	return &result
+}
+
+func getTestTaskResourceType(t *testing.T) tftypes.Type {
+	return getTestResourceSchema(t, &TaskResource{}).Schema.Type().TerraformType(context.Background())
+}
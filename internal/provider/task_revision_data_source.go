@@ -0,0 +1,162 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TaskRevisionDataSource{}
+
+func NewTaskRevisionDataSource() datasource.DataSource {
+	return &TaskRevisionDataSource{}
+}
+
+// TaskRevisionDataSource defines the data source implementation.
+type TaskRevisionDataSource struct {
+	client *sdk.TasksClient
+}
+
+// TaskRevisionDataSourceModel describes the data source data model.
+type TaskRevisionDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	TaskID types.String `tfsdk:"task_id"`
+
+	Active         types.Bool   `tfsdk:"active"`
+	SystemPrompt   types.String `tfsdk:"system_prompt"`
+	UserPrompt     types.String `tfsdk:"user_prompt"`
+	LLMModelID     types.String `tfsdk:"llm_model_id"`
+	ImageRequired  types.Bool   `tfsdk:"image_required"`
+	OptimiseImages types.Bool   `tfsdk:"optimise_images"`
+	OutputModality types.String `tfsdk:"output_modality"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+}
+
+func (d *TaskRevisionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_revision"
+}
+
+func (d *TaskRevisionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Task revision data source",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The revision identifier. Defaults to the active revision of the Task.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"task_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Task the revision belongs to.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:    true,
+				Description: "When `true` this is the active revision of the Task.",
+			},
+			"system_prompt": schema.StringAttribute{
+				Computed:    true,
+				Description: "The system prompt that is used to set the LLM context.",
+			},
+			"user_prompt": schema.StringAttribute{
+				Computed:    true,
+				Description: "The user prompt that is used to set the LLM context.",
+			},
+			"llm_model_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the LLM model used by the revision.",
+			},
+			"image_required": schema.BoolAttribute{
+				Computed: true,
+			},
+			"optimise_images": schema.BoolAttribute{
+				Computed: true,
+			},
+			"output_modality": schema.StringAttribute{
+				Computed: true,
+			},
+			"created_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the revision was created.",
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "The RFC 3339 timestamp at which the revision was last modified.",
+			},
+		},
+	}
+}
+
+func (d *TaskRevisionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TaskRevisionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TaskRevisionDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	task, err := d.client.Fetch(ctx, sdk.NewFetchTaskRequest(data.TaskID.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	var rev *entitites.Revision
+	if data.ID.IsNull() {
+		rev, err = task.GetActiveRevision()
+	} else {
+		rev, err = task.GetRevision(data.ID.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	data.ID = types.StringValue(rev.ID)
+	data.Active = types.BoolValue(rev.Active)
+	data.SystemPrompt = types.StringValue(rev.SystemPrompt)
+	data.UserPrompt = types.StringValue(rev.UserPrompt)
+	data.LLMModelID = types.StringValue(rev.LLMModelID)
+	data.ImageRequired = types.BoolValue(rev.ImageRequired)
+	data.OptimiseImages = types.BoolValue(rev.OptimiseImages)
+	data.OutputModality = types.StringValue(rev.OutputModality)
+	data.CreatedAt = timeToStringValue(rev.Created)
+	data.UpdatedAt = timeToStringValue(rev.Modified)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
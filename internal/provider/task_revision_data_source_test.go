@@ -0,0 +1,64 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+	"time"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/benbjohnson/clock"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRevisionDataSource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	mock := clock.NewMock()
+	mock.Set(time.Date(2025, 3, 14, 15, 9, 26, 0, time.UTC))
+	api.clock = mock
+	task := api.addTask("Joke")
+	active := task.Revisions[0]
+	draft := entitites.Revision{
+		ID:         "00000000-0000-0000-0000-0000000000ff",
+		UserPrompt: "Tell me a joke about {subject}",
+		Created:    mock.Now().Add(time.Hour),
+		Modified:   mock.Now().Add(2 * time.Hour),
+	}
+	task.Revisions = append([]entitites.Revision{draft}, task.Revisions...)
+
+	t.Run("test that it defaults to the active revision", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &TaskRevisionDataSource{client: api.client()}, TaskRevisionDataSourceModel{TaskID: types.StringValue(task.ID)})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(active.ID), data.ID)
+		assert.Equal(t, types.BoolValue(true), data.Active)
+		assert.Equal(t, types.StringValue("Tell me about {subject}"), data.UserPrompt)
+		assert.Equal(t, types.StringValue("2025-03-14T15:09:26Z"), data.CreatedAt)
+		assert.Equal(t, types.StringValue("2025-03-14T15:09:26Z"), data.UpdatedAt)
+	})
+
+	t.Run("test that it finds a revision by id", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &TaskRevisionDataSource{client: api.client()}, TaskRevisionDataSourceModel{
+			ID:     types.StringValue(draft.ID),
+			TaskID: types.StringValue(task.ID),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, types.StringValue(draft.ID), data.ID)
+		assert.Equal(t, types.BoolValue(false), data.Active)
+		assert.Equal(t, types.StringValue("Tell me a joke about {subject}"), data.UserPrompt)
+		assert.Equal(t, types.StringValue("2025-03-14T16:09:26Z"), data.CreatedAt)
+		assert.Equal(t, types.StringValue("2025-03-14T17:09:26Z"), data.UpdatedAt)
+	})
+
+	t.Run("test that an unknown revision id is an error", func(t *testing.T) {
+		_, diags := readTestDataSource(t, &TaskRevisionDataSource{client: api.client()}, TaskRevisionDataSourceModel{
+			ID:     types.StringValue("unknown"),
+			TaskID: types.StringValue(task.ID),
+		})
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "could not find revision unknown for task", diags[0].Summary())
+	})
+}
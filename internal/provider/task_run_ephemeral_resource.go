@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &TaskRunEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &TaskRunEphemeralResource{}
+
+func NewTaskRunEphemeralResource() ephemeral.EphemeralResource {
+	return &TaskRunEphemeralResource{}
+}
+
+// TaskRunEphemeralResource defines the ephemeral resource implementation.
+type TaskRunEphemeralResource struct {
+	client *sdk.TasksClient
+}
+
+// TaskRunEphemeralResourceModel describes the ephemeral resource data model.
+type TaskRunEphemeralResourceModel struct {
+	TaskID       types.String            `tfsdk:"task_id"`
+	Inputs       map[string]types.String `tfsdk:"inputs"`
+	ImagePath    types.String            `tfsdk:"image_path"`
+	RunID        types.String            `tfsdk:"run_id"`
+	RevisionID   types.String            `tfsdk:"revision_id"`
+	Output       types.String            `tfsdk:"output"`
+	InputTokens  types.Int64             `tfsdk:"input_tokens"`
+	OutputTokens types.Int64             `tfsdk:"output_tokens"`
+}
+
+func (e *TaskRunEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_task_run"
+}
+
+func (e *TaskRunEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs the active revision of a Task each time Terraform opens it, so that the output can be checked after an apply. The run is never stored in the plan or state.\n\nTerraform opens ephemeral resources during every plan as well as every apply, so each of them is a separate billed run of the Task. The run is skipped while any argument is unknown, such as the `task_id` of a Task that is yet to be created.",
+
+		Attributes: map[string]schema.Attribute{
+			"task_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the Task to run.",
+			},
+			"inputs": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "The values of the prompt parameters, keyed by parameter name.",
+			},
+			"image_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path of an image file to upload with the inputs.",
+			},
+			"run_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the run.",
+			},
+			"revision_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The ID of the revision that was run.",
+			},
+			"output": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The structured output of the run as a JSON string. Use `jsondecode` to read its fields.",
+			},
+			"input_tokens": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of tokens in the prompt.",
+			},
+			"output_tokens": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of tokens in the output.",
+			},
+		},
+	}
+}
+
+func (e *TaskRunEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *TaskRunEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data TaskRunEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// running with placeholders would still be billed, so the outputs stay
+	// unknown until the whole configuration is
+	if !req.Config.Raw.IsFullyKnown() {
+		data.RunID = types.StringUnknown()
+		data.RevisionID = types.StringUnknown()
+		data.Output = types.StringUnknown()
+		data.InputTokens = types.Int64Unknown()
+		data.OutputTokens = types.Int64Unknown()
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+		return
+	}
+
+	in := sdk.NewRunTaskRequest(data.TaskID.ValueString())
+	for k, v := range data.Inputs {
+		in.Input[k] = v.ValueString()
+	}
+
+	if !data.ImagePath.IsNull() {
+		image, err := newRunTaskFile(data.ImagePath.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("image_path"), err.Error(), "")
+			return
+		}
+		in.Files = append(in.Files, image)
+	}
+
+	run, err := e.client.Run(ctx, in)
+	if err != nil {
+		resp.Diagnostics.AddError(err.Error(), "")
+		return
+	}
+
+	if run.IsError {
+		resp.Diagnostics.AddError(fmt.Sprintf("task %q failed to run", data.TaskID.ValueString()), string(run.Response))
+		return
+	}
+
+	data.RunID = types.StringValue(run.ID)
+	data.RevisionID = types.StringValue(run.TaskRevisionID)
+	data.Output = types.StringValue(string(run.Response))
+	data.InputTokens = types.Int64Value(run.InputTokens)
+	data.OutputTokens = types.Int64Value(run.OutputTokens)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// newRunTaskFile reads the file at name for uploading with a run.
+func newRunTaskFile(name string) (sdk.RunTaskFile, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return sdk.RunTaskFile{}, err
+	}
+	return sdk.RunTaskFile{Name: filepath.Base(name), Data: data}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRunEphemeralResource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	task := api.addTask("Tell me a joke")
+
+	t.Run("test that it returns the output of the run", func(t *testing.T) {
+		config := newTestTaskRunConfig(task.ID)
+		config.Inputs = map[string]types.String{"subject": types.StringValue("chickens")}
+
+		data, diags := openTestEphemeralResource(t, &TaskRunEphemeralResource{client: api.client()}, config)
+		assert.False(t, diags.HasError(), diags)
+		assert.JSONEq(t, `{"echo": {"subject": "chickens"}}`, data.Output.ValueString())
+		assert.Equal(t, task.Revisions[0].ID, data.RevisionID.ValueString())
+		assert.False(t, data.RunID.IsNull())
+		assert.Equal(t, types.Int64Value(1), data.InputTokens)
+		assert.Equal(t, types.Int64Value(1), data.OutputTokens)
+	})
+
+	t.Run("test that it uploads the image", func(t *testing.T) {
+		image := filepath.Join(t.TempDir(), "chicken.png")
+		assert.NoError(t, os.WriteFile(image, []byte("\x89PNG\r\n\x1a\n0000"), 0o600))
+
+		config := newTestTaskRunConfig(task.ID)
+		config.ImagePath = types.StringValue(image)
+
+		_, diags := openTestEphemeralResource(t, &TaskRunEphemeralResource{client: api.client()}, config)
+		assert.False(t, diags.HasError(), diags)
+		assert.Equal(t, []string{"chicken.png"}, api.runs[len(api.runs)-1].files)
+	})
+
+	t.Run("test that a missing image is an error", func(t *testing.T) {
+		config := newTestTaskRunConfig(task.ID)
+		config.ImagePath = types.StringValue(filepath.Join(t.TempDir(), "missing.png"))
+
+		_, diags := openTestEphemeralResource(t, &TaskRunEphemeralResource{client: api.client()}, config)
+		assert.Equal(t, 1, diags.ErrorsCount())
+	})
+
+	t.Run("test that it does not run while the configuration is unknown", func(t *testing.T) {
+		runs := len(api.runs)
+
+		config := newTestTaskRunConfig(task.ID)
+		config.Inputs = map[string]types.String{"subject": types.StringUnknown()}
+
+		data, diags := openTestEphemeralResource(t, &TaskRunEphemeralResource{client: api.client()}, config)
+		assert.False(t, diags.HasError(), diags)
+		assert.True(t, data.Output.IsUnknown())
+		assert.True(t, data.RunID.IsUnknown())
+		assert.Equal(t, runs, len(api.runs))
+	})
+
+	t.Run("test that running an unknown task is an error", func(t *testing.T) {
+		_, diags := openTestEphemeralResource(t, &TaskRunEphemeralResource{client: api.client()}, newTestTaskRunConfig("unknown"))
+		assert.Equal(t, 1, diags.ErrorsCount())
+		assert.Equal(t, "cannot run task, expected status code 200 but got 404.", diags[0].Summary())
+	})
+}
+
+func newTestTaskRunConfig(taskID string) TaskRunEphemeralResourceModel {
+	return TaskRunEphemeralResourceModel{
+		TaskID:       types.StringValue(taskID),
+		ImagePath:    types.StringNull(),
+		RunID:        types.StringNull(),
+		RevisionID:   types.StringNull(),
+		Output:       types.StringNull(),
+		InputTokens:  types.Int64Null(),
+		OutputTokens: types.Int64Null(),
+	}
+}
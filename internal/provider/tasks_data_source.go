@@ -0,0 +1,185 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-tasks/internal/sdk"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TasksDataSource{}
+
+func NewTasksDataSource() datasource.DataSource {
+	return &TasksDataSource{}
+}
+
+// TasksDataSource defines the data source implementation.
+type TasksDataSource struct {
+	client *sdk.TasksClient
+}
+
+// TasksDataSourceModel describes the data source data model.
+type TasksDataSourceModel struct {
+	Name    types.String `tfsdk:"name"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+
+	Tasks []TaskSummaryModel `tfsdk:"tasks"`
+}
+
+// TaskSummaryModel describes a single task in a listing.
+type TaskSummaryModel struct {
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	ProjectID        types.String `tfsdk:"project_id"`
+	Description      types.String `tfsdk:"description"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Public           types.Bool   `tfsdk:"public"`
+	ActiveRevisionID types.String `tfsdk:"active_revision_id"`
+	LLMModelID       types.String `tfsdk:"llm_model_id"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+}
+
+func NewTaskSummaryModel(task entitites.Task) TaskSummaryModel {
+	summary := TaskSummaryModel{
+		ID:               types.StringValue(task.ID),
+		Name:             types.StringValue(task.Name),
+		ProjectID:        types.StringValue(task.ProjectID),
+		Description:      types.StringValue(task.Description),
+		Enabled:          types.BoolValue(task.Enabled),
+		Public:           types.BoolValue(task.Public),
+		ActiveRevisionID: types.StringNull(),
+		LLMModelID:       types.StringNull(),
+		CreatedAt:        timeToStringValue(task.Created),
+		UpdatedAt:        timeToStringValue(task.Modified),
+	}
+
+	if rev, err := task.GetActiveRevision(); err == nil {
+		summary.ActiveRevisionID = types.StringValue(rev.ID)
+		summary.LLMModelID = types.StringValue(rev.LLMModelID)
+	}
+
+	return summary
+}
+
+func (d *TasksDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tasks"
+}
+
+func (d *TasksDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Tasks data source. Lists the Tasks in the project, optionally filtered.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return Tasks whose name contains this value.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return Tasks whose enabled flag matches this value.",
+			},
+			"tasks": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "The Tasks matching every filter.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Task identifier",
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"project_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"enabled": schema.BoolAttribute{
+							Computed: true,
+						},
+						"public": schema.BoolAttribute{
+							Computed: true,
+						},
+						"active_revision_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"llm_model_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "The ID of the LLM model used by the active revision.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed: true,
+						},
+						"updated_at": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *TasksDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*sdk.TasksClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *sdk.TasksClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *TasksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TasksDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	in := sdk.NewListTasksRequest()
+	in.Name = data.Name.ValueString()
+	if !data.Enabled.IsNull() {
+		enabled := data.Enabled.ValueBool()
+		in.Enabled = &enabled
+	}
+
+	data.Tasks = []TaskSummaryModel{}
+
+	for task, err := range d.client.AllTasks(ctx, in) {
+		if err != nil {
+			resp.Diagnostics.AddError(err.Error(), "")
+			return
+		}
+		data.Tasks = append(data.Tasks, NewTaskSummaryModel(task))
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTasksDataSource(t *testing.T) {
+
+	api := newFakeTasksAPI(t)
+	for i := 0; i < 120; i++ {
+		api.addTask("Filler")
+	}
+	joke := api.addTask("Joke")
+	api.addTask("Joke of the day").Enabled = false
+
+	t.Run("test that it lists every task across pages", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &TasksDataSource{client: api.client()}, TasksDataSourceModel{})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, data.Tasks, 122)
+	})
+
+	t.Run("test that filters are combined", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &TasksDataSource{client: api.client()}, TasksDataSourceModel{
+			Name:    types.StringValue("Joke"),
+			Enabled: types.BoolValue(true),
+		})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, data.Tasks, 1)
+		assert.Equal(t, types.StringValue(joke.ID), data.Tasks[0].ID)
+		assert.Equal(t, types.StringValue(joke.Revisions[0].ID), data.Tasks[0].ActiveRevisionID)
+	})
+
+	t.Run("test that it filters disabled tasks", func(t *testing.T) {
+		data, diags := readTestDataSource(t, &TasksDataSource{client: api.client()}, TasksDataSourceModel{Enabled: types.BoolValue(false)})
+		assert.False(t, diags.HasError(), diags)
+		assert.Len(t, data.Tasks, 1)
+		assert.Equal(t, types.StringValue("Joke of the day"), data.Tasks[0].Name)
+	})
+}
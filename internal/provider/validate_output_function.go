@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateOutputFunction{}
+
+func NewValidateOutputFunction() function.Function {
+	return &ValidateOutputFunction{}
+}
+
+// ValidateOutputFunction defines the function implementation.
+type ValidateOutputFunction struct{}
+
+func (f *ValidateOutputFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_output"
+}
+
+func (f *ValidateOutputFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Validates an output against an output format",
+		MarkdownDescription: "Checks a JSON document, such as a recorded Task output or a test fixture, against the `output_format` of a `rightbrain_task`. Returns a sorted list of violations, each prefixed with the path of the offending value, such as `tags[1]: expected str, got int`. The list is empty when the output conforms.",
+
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:        "output_format",
+				ElementType: types.StringType,
+				Description: "The output format to validate against.",
+			},
+			function.StringParameter{
+				Name:        "json",
+				Description: "The JSON encoding of the output.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *ValidateOutputFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var format map[string]string
+	var output string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &format, &output))
+
+	if resp.Error != nil {
+		return
+	}
+
+	fields, err := sdk.ParseOutputFormat(format)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	value, err := sdk.DecodeOutput([]byte(output))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	violations := sdk.ValidateOutput(fields, value)
+	if violations == nil {
+		violations = []string{}
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, violations))
+}
@@ -0,0 +1,52 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOutputFunction(t *testing.T) {
+
+	format := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"joke": types.StringValue("str"),
+		"tags": types.StringValue(`{"type": "list", "item_type": "str"}`),
+	})
+
+	t.Run("test that a conforming output has no violations", func(t *testing.T) {
+		resp := runTestFunction(t, NewValidateOutputFunction(), types.ListUnknown(types.StringType),
+			format, types.StringValue(`{"joke": "Why did the chicken cross the road?", "tags": ["chickens"]}`))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{}), resp.Result.Value())
+	})
+
+	t.Run("test that it returns every violation", func(t *testing.T) {
+		resp := runTestFunction(t, NewValidateOutputFunction(), types.ListUnknown(types.StringType),
+			format, types.StringValue(`{"tags": ["chickens", 2], "rating": 7}`))
+		assert.Nil(t, resp.Error)
+		assert.Equal(t, types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("joke: missing"),
+			types.StringValue("rating: unexpected field"),
+			types.StringValue("tags[1]: expected str, got int"),
+		}), resp.Result.Value())
+	})
+
+	t.Run("test that invalid json is an argument error", func(t *testing.T) {
+		resp := runTestFunction(t, NewValidateOutputFunction(), types.ListUnknown(types.StringType),
+			format, types.StringValue(`{"joke": `))
+		assert.Equal(t, function.NewArgumentFuncError(1, "unexpected EOF"), resp.Error)
+	})
+
+	t.Run("test that an invalid output format is an argument error", func(t *testing.T) {
+		resp := runTestFunction(t, NewValidateOutputFunction(), types.ListUnknown(types.StringType),
+			types.MapValueMust(types.StringType, map[string]attr.Value{"joke": types.StringValue("text")}),
+			types.StringValue(`{"joke": "Why did the chicken cross the road?"}`))
+		assert.Equal(t, function.NewArgumentFuncError(0, `output field "joke": unknown type "text", expected one of str, int, float, bool, list, object`), resp.Error)
+	})
+}
@@ -9,28 +9,43 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
+	"net/url"
+	"strconv"
 	entitites "terraform-provider-tasks/internal/sdk/entities"
+	"time"
+
+	"github.com/benbjohnson/clock"
 )
 
 const (
 	DefaultAPIVersion = "v1"
+	DefaultPageLimit  = 100
 )
 
 func NewTasksClient(log Log, httpClient HttpClient, tokenStore *TokenStore, config Config) *TasksClient {
+	clk := config.Clock
+	if clk == nil {
+		clk = clock.New()
+	}
 	return &TasksClient{
 		log:        log,
+		clock:      clk,
 		tokenStore: tokenStore,
 		httpClient: httpClient,
 		config:     config,
+		models:     newModelCache(clk, config.ModelCacheTTL),
 	}
 }
 
 type TasksClient struct {
 	log        Log
+	clock      clock.Clock
 	tokenStore *TokenStore
 	httpClient HttpClient
 	config     Config
+	models     *modelCache
 }
 
 func (tc *TasksClient) Fetch(ctx context.Context, in FetchTaskRequest) (*entitites.Task, error) {
@@ -61,6 +76,82 @@ func (tc *TasksClient) Fetch(ctx context.Context, in FetchTaskRequest) (*entitit
 	return task, nil
 }
 
+func (tc *TasksClient) ListTasks(ctx context.Context, in ListTasksRequest) (*entitites.TaskList, error) {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(in.Page))
+	query.Set("page_limit", strconv.Itoa(in.PageLimit))
+	if in.Name != "" {
+		query.Set("name", in.Name)
+	}
+	if in.Enabled != nil {
+		query.Set("enabled", strconv.FormatBool(*in.Enabled))
+	}
+	url := fmt.Sprintf("%s/task?%s", tc.getBaseAPIURL(), query.Encode())
+	tc.log.Info("listing tasks", "url", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	res, err := tc.DoWithAuth(ctx, req)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	if err := tc.assertStatusCode("cannot list tasks", http.StatusOK, res); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	list := new(entitites.TaskList)
+	if err := json.NewDecoder(res.Body).Decode(&list); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	return list, nil
+}
+
+// AllTasks walks every page of tasks matching in, starting at in.Page, and
+// yields them one at a time. Iteration stops after the first error.
+func (tc *TasksClient) AllTasks(ctx context.Context, in ListTasksRequest) iter.Seq2[entitites.Task, error] {
+	return func(yield func(entitites.Task, error) bool) {
+		for {
+			page, err := tc.ListTasks(ctx, in)
+			if err != nil {
+				yield(entitites.Task{}, err)
+				return
+			}
+			for _, task := range page.Results {
+				if !yield(task, nil) {
+					return
+				}
+			}
+			if !page.Pagination.HasNext || len(page.Results) == 0 {
+				return
+			}
+			in.Page++
+		}
+	}
+}
+
+// FetchAllTasks walks every task matching in like AllTasks, but yields each
+// task as returned by Fetch. Listings do not necessarily carry every revision
+// setting, so callers that need the whole task, rather than a summary, must
+// fetch it. Iteration stops after the first error.
+func (tc *TasksClient) FetchAllTasks(ctx context.Context, in ListTasksRequest) iter.Seq2[*entitites.Task, error] {
+	return func(yield func(*entitites.Task, error) bool) {
+		for summary, err := range tc.AllTasks(ctx, in) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			task, err := tc.Fetch(ctx, NewFetchTaskRequest(summary.ID))
+			if !yield(task, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
 func (tc *TasksClient) Create(ctx context.Context, in CreateTaskRequest) (*entitites.Task, error) {
 	var data = new(bytes.Buffer)
 	if err := json.NewEncoder(data).Encode(&in); err != nil {
@@ -141,8 +232,17 @@ func (tc *TasksClient) Delete(ctx context.Context, in DeleteTaskRequest) error {
 	return nil
 }
 
+// GetAvailableLLMModels returns the model catalog, served from a cache shared
+// by every caller of this client for Config.ModelCacheTTL.
 func (tc *TasksClient) GetAvailableLLMModels(ctx context.Context) ([]entitites.Model, error) {
+	return tc.models.get(ctx, tc.FetchAvailableLLMModels)
+}
+
+// FetchAvailableLLMModels requests the model catalog from the API, bypassing
+// the cache.
+func (tc *TasksClient) FetchAvailableLLMModels(ctx context.Context) ([]entitites.Model, error) {
 	url := fmt.Sprintf("%s/model", tc.getBaseAPIURL())
+	tc.log.Info("fetching model list", "url", url)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
@@ -168,6 +268,12 @@ func (tc *TasksClient) GetAvailableLLMModels(ctx context.Context) ([]entitites.M
 	return models, nil
 }
 
+// MintAccessToken requests an access token for the configured client that is
+// independent of the one the client itself uses.
+func (tc *TasksClient) MintAccessToken(ctx context.Context) (*Token, error) {
+	return tc.tokenStore.Mint(ctx, tc.config.RightbrainClientID, tc.config.RightbrainClientSecret)
+}
+
 func (tc *TasksClient) DoWithAuth(ctx context.Context, req *http.Request) (*http.Response, error) {
 	token, err := tc.tokenStore.Fetch(ctx, tc.config.RightbrainClientID, tc.config.RightbrainClientSecret)
 	if err != nil {
@@ -177,6 +283,39 @@ func (tc *TasksClient) DoWithAuth(ctx context.Context, req *http.Request) (*http
 	return tc.httpClient.Do(req)
 }
 
+// Now returns the current time according to the clock of the client.
+func (tc *TasksClient) Now() time.Time {
+	return tc.clock.Now()
+}
+
+// OrgID returns the ID of the org the client operates on.
+func (tc *TasksClient) OrgID() string {
+	return tc.config.RightbrainOrgID
+}
+
+// ProjectID returns the ID of the project the client operates on.
+func (tc *TasksClient) ProjectID() string {
+	return tc.config.RightbrainProjectID
+}
+
+// ForProject returns a client for another project in the same org, sharing
+// the credentials and token of tc.
+func (tc *TasksClient) ForProject(projectID string) *TasksClient {
+	if projectID == "" || projectID == tc.config.RightbrainProjectID {
+		return tc
+	}
+	config := tc.config
+	config.RightbrainProjectID = projectID
+	return &TasksClient{
+		log:        tc.log,
+		clock:      tc.clock,
+		tokenStore: tc.tokenStore,
+		httpClient: tc.httpClient,
+		config:     config,
+		models:     newModelCache(tc.clock, config.ModelCacheTTL),
+	}
+}
+
 func (tc *TasksClient) getBaseAPIURL() string {
 	return fmt.Sprintf("%s/api/%s/org/%s/project/%s", tc.config.RightbrainAPIHost, DefaultAPIVersion, tc.config.RightbrainOrgID, tc.config.RightbrainProjectID)
 }
@@ -11,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"terraform-provider-tasks/internal/sdk"
 
@@ -82,6 +83,242 @@ func TestTasksClient(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 1, calls)
 		assert.Equal(t, "019011e6-e530-3aca-6cf7-2973387c255d", task.ID)
+		assert.Equal(t, time.Date(2024, 6, 13, 14, 1, 3, 0, time.UTC), task.Created)
+		assert.Equal(t, time.Date(2024, 6, 13, 14, 1, 3, 0, time.UTC), task.Modified)
+		assert.Equal(t, time.Date(2024, 6, 13, 14, 1, 3, 0, time.UTC), task.Revisions[0].Created)
+		assert.Equal(t, time.Date(2024, 6, 13, 14, 1, 3, 0, time.UTC), task.Revisions[0].Modified)
+		assert.Equal(t, "bool", task.Revisions[0].OutputFormat.StringMap()["compliance"])
+		assert.Equal(t, `{"type":"str"}`, task.Revisions[0].OutputFormat.StringMap()["hint"])
+	})
+
+	t.Run("test that it lists a page of tasks", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "/api/v1/org/00000001-00000000-00000000-00000000/project/019010a2-8327-2607-11d7-41bb0a8936d4/task", r.URL.Path)
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			assert.Equal(t, "100", r.URL.Query().Get("page_limit"))
+			assert.Equal(t, "Fintech Pre-Triage", r.URL.Query().Get("name"))
+			data := getTestFixture(t, "task.json")
+			_, _ = fmt.Fprintf(w, `{"results": [%s], "pagination": {"page": 2, "page_limit": 100, "total": 101, "has_next": false}}`, data)
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		in := sdk.NewListTasksRequest()
+		in.Page = 2
+		in.Name = "Fintech Pre-Triage"
+		list, err := tc.ListTasks(ctx, in)
+		assert.NoError(t, err)
+		assert.Len(t, list.Results, 1)
+		assert.Equal(t, "019011e6-e530-3aca-6cf7-2973387c255d", list.Results[0].ID)
+		assert.Equal(t, 101, list.Pagination.Total)
+		assert.False(t, list.Pagination.HasNext)
+	})
+
+	t.Run("test that it walks every page of tasks", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		var pages []string
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			pages = append(pages, page)
+			assert.Equal(t, "true", r.URL.Query().Get("enabled"))
+			_, _ = fmt.Fprintf(w, `{"results": [{"id": "task-%s-a"}, {"id": "task-%s-b"}], "pagination": {"page": %s, "page_limit": 2, "total": 6, "has_next": %t}}`, page, page, page, page != "3")
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		enabled := true
+		in := sdk.NewListTasksRequest()
+		in.PageLimit = 2
+		in.Enabled = &enabled
+
+		var ids []string
+		for task, err := range tc.AllTasks(ctx, in) {
+			assert.NoError(t, err)
+			ids = append(ids, task.ID)
+		}
+		assert.Equal(t, []string{"task-1-a", "task-1-b", "task-2-a", "task-2-b", "task-3-a", "task-3-b"}, ids)
+		assert.Equal(t, []string{"1", "2", "3"}, pages)
+
+		pages = nil
+		for task := range tc.AllTasks(ctx, in) {
+			if task.ID == "task-1-b" {
+				break
+			}
+		}
+		assert.Equal(t, []string{"1"}, pages)
+	})
+
+	t.Run("test that it walks the recorded task pages", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		var pages []string
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/org/00000001-00000000-00000000-00000000/project/019010a2-8327-2607-11d7-41bb0a8936d4/task", r.URL.Path)
+			assert.Equal(t, "2", r.URL.Query().Get("page_limit"))
+			page := r.URL.Query().Get("page")
+			pages = append(pages, page)
+			_, _ = w.Write(getTestFixture(t, "task_list_page_"+page+".json"))
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		in := sdk.NewListTasksRequest()
+		in.PageLimit = 2
+
+		var names []string
+		for task, err := range tc.AllTasks(ctx, in) {
+			assert.NoError(t, err)
+			assert.Equal(t, "019010a2-8327-2607-11d7-41bb0a8936d4", task.ProjectID)
+			names = append(names, task.Name)
+		}
+		assert.Equal(t, []string{"Fintech Pre-Triage", "Fintech Post-Triage", "Fintech Dispute Classifier"}, names)
+		assert.Equal(t, []string{"1", "2"}, pages)
+	})
+
+	t.Run("test that it fetches every listed task", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		var fetched []string
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/task") {
+				_, _ = fmt.Fprint(w, `{"results": [{"id": "019011e6-e530-3aca-6cf7-2973387c255d"}, {"id": "missing"}, {"id": "unreached"}], "pagination": {"page": 1, "page_limit": 100, "total": 3, "has_next": false}}`)
+				return
+			}
+			fetched = append(fetched, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			if strings.HasSuffix(r.URL.Path, "/missing") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(getTestFixture(t, "task.json"))
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+
+		var names []string
+		var errs []error
+		for task, err := range tc.FetchAllTasks(ctx, sdk.NewListTasksRequest()) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			names = append(names, task.Name)
+		}
+		assert.Equal(t, []string{"019011e6-e530-3aca-6cf7-2973387c255d", "missing"}, fetched)
+		assert.Len(t, names, 1)
+		assert.NotEmpty(t, names[0])
+		assert.Len(t, errs, 1)
+	})
+
+	t.Run("test that it stops walking tasks on error", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+
+		var errs []error
+		for _, err := range tc.AllTasks(ctx, sdk.NewListTasksRequest()) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 1)
+		assert.EqualError(t, errs[0], "cannot list tasks, expected status code 200 but got 500.")
+	})
+
+	t.Run("test that it decodes the model lifecycle", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.True(t, strings.HasSuffix(r.RequestURI, "/org/00000001-00000000-00000000-00000000/project/019010a2-8327-2607-11d7-41bb0a8936d4/model"))
+			data := getTestFixture(t, "models.json")
+			_, _ = w.Write(data)
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		models, err := tc.FetchAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, models, 3)
+
+		// lifecycle fields that are absent or null stay nil
+		for _, model := range models[:2] {
+			assert.False(t, model.IsDeprecated())
+			assert.Nil(t, model.Deprecated)
+			assert.Nil(t, model.SunsetDate)
+			assert.Nil(t, model.ReplacementModelID)
+		}
+
+		assert.True(t, models[2].IsDeprecated())
+		assert.Equal(t, "2026-12-31", *models[2].SunsetDate)
+		assert.Equal(t, models[1].ID, *models[2].ReplacementModelID)
+		assert.Nil(t, models[2].SupportsImageOutput)
 	})
 
 	t.Run("test that it sends a create request", func(t *testing.T) {
@@ -153,6 +390,42 @@ func TestTasksClient(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "019011e6-e530-3aca-6cf7-2973387c255d", task.ID)
 	})
+
+	t.Run("test that it fetches a task from another project", func(t *testing.T) {
+		var calls int
+
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, err := w.Write(mockOAuthTokenResponse)
+			assert.NoError(t, err)
+		}))
+		defer mockOAuthServer.Close()
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/org/00000001-00000000-00000000-00000000/project/01901111-0000-0000-0000-000000000000/task/019011e6-e530-3aca-6cf7-2973387c255d", r.URL.Path)
+			data := getTestFixture(t, "task.json")
+			_, _ = w.Write(data)
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		other := tc.ForProject("01901111-0000-0000-0000-000000000000")
+		assert.Equal(t, "01901111-0000-0000-0000-000000000000", other.ProjectID())
+		assert.Equal(t, "019010a2-8327-2607-11d7-41bb0a8936d4", tc.ProjectID())
+		assert.Same(t, tc, tc.ForProject(""))
+
+		_, err = other.Fetch(ctx, sdk.NewFetchTaskRequest("019011e6-e530-3aca-6cf7-2973387c255d"))
+		assert.NoError(t, err)
+		_, err = other.Fetch(ctx, sdk.NewFetchTaskRequest("019011e6-e530-3aca-6cf7-2973387c255d"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, calls)
+	})
 }
 
 // nolint:unparam
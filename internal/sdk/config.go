@@ -3,10 +3,22 @@
 
 package sdk
 
+import (
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
 type Config struct {
 	RightbrainAPIHost      string
 	RightbrainClientID     string
 	RightbrainClientSecret string
 	RightbrainOrgID        string
 	RightbrainProjectID    string
+	// ModelCacheTTL is how long the model catalog is reused for, zero
+	// disables caching.
+	ModelCacheTTL time.Duration
+	// Clock is the time source for the model cache and token renewal, nil
+	// uses the wall clock.
+	Clock clock.Clock
 }
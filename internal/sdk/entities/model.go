@@ -10,4 +10,30 @@ type Model struct {
 	Provider       string `json:"provider"`
 	Description    string `json:"description"`
 	SupportsVision bool   `json:"supports_vision"`
+	// SupportsImageOutput is nil when the API does not report whether the
+	// model can produce images.
+	SupportsImageOutput *bool `json:"supports_image_output"`
+	// Deprecated models remain usable until their SunsetDate, after which
+	// they are removed from the catalog. The lifecycle fields are nil when
+	// the API omits them or reports them as null, which it does for models
+	// that are not being retired.
+	Deprecated         *bool   `json:"deprecated"`
+	SunsetDate         *string `json:"sunset_date"`
+	ReplacementModelID *string `json:"replacement_model_id"`
+}
+
+// IsDeprecated returns true when the model is being retired.
+func (m *Model) IsDeprecated() bool {
+	return m.Deprecated != nil && *m.Deprecated
+}
+
+// FindModelByID returns the model with the given ID, or nil when it is not in
+// models.
+func FindModelByID(models []Model, id string) *Model {
+	for i := range models {
+		if models[i].ID == id {
+			return &models[i]
+		}
+	}
+	return nil
 }
@@ -3,15 +3,22 @@
 
 package entitites
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
 
 // Root represents the overall response structure.
 type Task struct {
 	AccessToken     string     `json:"access_token"`
+	Created         time.Time  `json:"created"`
 	Description     string     `json:"description"`
 	Enabled         bool       `json:"enabled"`
 	ExposedToAgents bool       `json:"exposed_to_agents"`
 	ID              string     `json:"id"`
+	Modified        time.Time  `json:"modified"`
 	Name            string     `json:"name"`
 	ProjectID       string     `json:"project_id"`
 	Public          bool       `json:"public"`
@@ -27,6 +34,15 @@ func (t *Task) GetActiveRevision() (*Revision, error) {
 	return nil, fmt.Errorf("could not find active revision for task")
 }
 
+func (t *Task) GetRevision(id string) (*Revision, error) {
+	for _, r := range t.Revisions {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find revision %s for task", id)
+}
+
 func (t *Task) GetLatestRevision() (*Revision, error) {
 	if len(t.Revisions) == 0 {
 		return nil, fmt.Errorf("could not find latest revision for task")
@@ -37,15 +53,17 @@ func (t *Task) GetLatestRevision() (*Revision, error) {
 // Revision represents a single revision in the "revisions" array.
 type Revision struct {
 	Active          bool              `json:"active"`
+	Created         time.Time         `json:"created"`
 	ID              string            `json:"id"`
 	ImageRequired   bool              `json:"image_required"`
 	InputParams     []string          `json:"input_params"`
 	InputProcessors *[]InputProcessor `json:"input_processors"`
 	LLMModelID      string            `json:"llm_model_id"`
+	Modified        time.Time         `json:"modified"`
 	OptimiseImages  bool              `json:"optimise_images"`
 	OutputFormat    OutputFormat      `json:"output_format"`
 	OutputModality  string            `json:"output_modality"`
-	RAG             RAG               `json:"rag"`
+	RAG             *RAG              `json:"rag"`
 	SystemPrompt    string            `json:"system_prompt"`
 	TaskForwarderID string            `json:"task_forwarder_id"`
 	UserPrompt      string            `json:"user_prompt"`
@@ -55,19 +73,58 @@ func (r *Revision) HasInputProcessors() bool {
 	return r.InputProcessors != nil && len(*r.InputProcessors) > 0
 }
 
+func (r *Revision) HasRAG() bool {
+	return r.RAG != nil && (r.RAG.CollectionID != "" || r.RAG.RAGParam != "")
+}
+
 type InputProcessor struct {
 	ParamName      string            `json:"param_name"`
 	InputProcessor string            `json:"input_processor"`
 	Config         map[string]string `json:"config"`
 }
 
-// OutputFormat represents the structure of the output format in a revision.
-type OutputFormat struct {
-	Compliance  string `json:"compliance"`
-	Hint        Hint   `json:"hint"`
-	Match       Match  `json:"match"`
-	Description string `json:"description"`
-	Rationale   string `json:"rationale"`
+// OutputFormat maps each output field of a revision to either a type name,
+// such as "str", or an object describing the field, such as
+// {"type": "str", "description": "..."}.
+type OutputFormat map[string]any
+
+// NewOutputFormat returns the output format described by Terraform
+// configuration, the inverse of StringMap. Values holding a JSON object, as
+// written with jsonencode or returned by output_format_from_jsonschema, are
+// decoded so that the API receives an object rather than a string.
+func NewOutputFormat(values map[string]string) (OutputFormat, error) {
+	of := make(OutputFormat, len(values))
+	for k, v := range values {
+		if !strings.HasPrefix(strings.TrimSpace(v), "{") {
+			of[k] = v
+			continue
+		}
+		var field map[string]any
+		if err := json.Unmarshal([]byte(v), &field); err != nil {
+			return nil, fmt.Errorf("output field %q: %w", k, err)
+		}
+		of[k] = field
+	}
+	return of, nil
+}
+
+// StringMap returns the output format with object descriptions encoded as
+// JSON, the representation used by Terraform configuration. Object keys are
+// sorted, so equal descriptions are always encoded the same way.
+func (of OutputFormat) StringMap() map[string]string {
+	result := make(map[string]string, len(of))
+	for k, v := range of {
+		if str, ok := v.(string); ok {
+			result[k] = str
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			data = []byte(fmt.Sprint(v))
+		}
+		result[k] = string(data)
+	}
+	return result
 }
 
 // RAG represents the RAG parameters in a revision.
@@ -76,10 +133,16 @@ type RAG struct {
 	RAGParam     string `json:"rag_param"`
 }
 
-type Hint struct {
-	Type string `json:"type"` // The type of the hint (e.g., "str")
+// TaskList is a single page of tasks returned when listing tasks.
+type TaskList struct {
+	Results    []Task     `json:"results"`
+	Pagination Pagination `json:"pagination"`
 }
 
-type Match struct {
-	Type string `json:"type"` // The type of the match (e.g., "str")
+// Pagination describes where a page sits in a listing.
+type Pagination struct {
+	Page      int  `json:"page"`
+	PageLimit int  `json:"page_limit"`
+	Total     int  `json:"total"`
+	HasNext   bool `json:"has_next"`
 }
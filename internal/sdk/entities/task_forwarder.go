@@ -0,0 +1,13 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package entitites
+
+// TaskForwarder forwards the output of a Task run to an external endpoint.
+type TaskForwarder struct {
+	DestinationURL string            `json:"destination_url"`
+	Headers        map[string]string `json:"headers"`
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	ProjectID      string            `json:"project_id"`
+}
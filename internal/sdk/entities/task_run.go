@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package entitites
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// TaskRun is the result of running a Task.
+type TaskRun struct {
+	ID             string          `json:"id"`
+	TaskID         string          `json:"task_id"`
+	TaskRevisionID string          `json:"task_revision_id"`
+	Response       json.RawMessage `json:"response"`
+	IsError        bool            `json:"is_error"`
+	InputTokens    int64           `json:"input_tokens"`
+	OutputTokens   int64           `json:"output_tokens"`
+	Created        time.Time       `json:"created"`
+}
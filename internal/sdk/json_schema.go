@@ -0,0 +1,228 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaKeywords are the keywords that OutputFormatFromJSONSchema
+// understands. Any other keyword could change the meaning of the schema in a
+// way an output format cannot express, so it is rejected.
+var jsonSchemaKeywords = []string{
+	"$schema", "$id", "$comment", "title", "description", "examples", "default",
+	"type", "enum", "properties", "required", "additionalProperties", "items",
+}
+
+var jsonSchemaTypes = map[string]string{
+	"string":  "str",
+	"integer": "int",
+	"number":  "float",
+	"boolean": "bool",
+	"array":   "list",
+	"object":  "object",
+}
+
+// OutputFormatFromJSONSchema converts a JSON Schema that describes an object
+// into an output format. Every property becomes a field, descriptions and
+// string enums are kept, and arrays and nested objects become list and object
+// fields.
+func OutputFormatFromJSONSchema(data []byte) (map[string]OutputField, error) {
+	var schema any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+
+	field, err := outputFieldFromJSONSchema("schema", schema)
+	if err != nil {
+		return nil, err
+	}
+	if field.Type != "object" || len(field.NestedStructure) == 0 {
+		return nil, fmt.Errorf("schema: must describe an object with at least one property")
+	}
+
+	return field.NestedStructure, nil
+}
+
+func outputFieldFromJSONSchema(path string, v any) (OutputField, error) {
+	var field OutputField
+
+	schema, ok := v.(map[string]any)
+	if !ok {
+		return field, fmt.Errorf("%s: must be an object", path)
+	}
+	for keyword := range schema {
+		if !slices.Contains(jsonSchemaKeywords, keyword) {
+			return field, fmt.Errorf("%s: unsupported keyword %q", path, keyword)
+		}
+	}
+
+	if description, ok := schema["description"]; ok {
+		if field.Description, ok = description.(string); !ok {
+			return field, fmt.Errorf("%s: description must be a string", path)
+		}
+	}
+
+	typ, ok := schema["type"].(string)
+	if !ok {
+		return field, fmt.Errorf("%s: type must be a single type name", path)
+	}
+	if field.Type, ok = jsonSchemaTypes[typ]; !ok {
+		return field, fmt.Errorf("%s: unsupported type %q", path, typ)
+	}
+
+	if enum, ok := schema["enum"]; ok {
+		values, ok := enum.([]any)
+		if !ok || field.Type != "str" {
+			return field, fmt.Errorf("%s: enum is only supported as a list of strings", path)
+		}
+		for _, value := range values {
+			option, ok := value.(string)
+			if !ok {
+				return field, fmt.Errorf("%s: enum is only supported as a list of strings", path)
+			}
+			field.Options = append(field.Options, option)
+		}
+	}
+
+	switch field.Type {
+	case "list":
+		items, ok := schema["items"]
+		if !ok {
+			break
+		}
+		item, err := outputFieldFromJSONSchema(path+"[]", items)
+		if err != nil {
+			return field, err
+		}
+		switch {
+		case item.Type == "list":
+			return field, fmt.Errorf("%s: lists of lists are not supported", path)
+		case item.Options != nil:
+			return field, fmt.Errorf("%s[]: enum is not supported for list items", path)
+		}
+		field.ItemType = item.Type
+		field.NestedStructure = item.NestedStructure
+	case "object":
+		if additional, ok := schema["additionalProperties"]; ok && additional != false {
+			return field, fmt.Errorf("%s: additionalProperties is only supported when false", path)
+		}
+		properties, ok := schema["properties"]
+		if !ok {
+			break
+		}
+		props, ok := properties.(map[string]any)
+		if !ok {
+			return field, fmt.Errorf("%s: properties must be an object", path)
+		}
+		field.NestedStructure = make(map[string]OutputField, len(props))
+		for name, prop := range props {
+			nested, err := outputFieldFromJSONSchema(joinSchemaPath(path, name), prop)
+			if err != nil {
+				return field, err
+			}
+			field.NestedStructure[name] = nested
+		}
+		if err := checkJSONSchemaRequired(path, schema["required"], props); err != nil {
+			return field, err
+		}
+	}
+
+	return field, nil
+}
+
+// checkJSONSchemaRequired rejects a required list that leaves out any of the
+// properties. Every field of an output format is present in the output of a
+// run, so a schema with optional properties cannot be expressed.
+func checkJSONSchemaRequired(path string, required any, props map[string]any) error {
+	values, ok := required.([]any)
+	if required != nil && !ok {
+		return fmt.Errorf("%s: required must be a list of property names", path)
+	}
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		name, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: required must be a list of property names", path)
+		}
+		names = append(names, name)
+	}
+
+	missing := make([]string, 0)
+	for name := range props {
+		if !slices.Contains(names, name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("%s: required must list every property, missing %s", path, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func joinSchemaPath(path, name string) string {
+	if path == "schema" {
+		return name
+	}
+	return path + "." + name
+}
+
+// OutputFormatToJSONSchema converts an output format into a JSON Schema that
+// describes the outputs it allows, the inverse of OutputFormatFromJSONSchema.
+func OutputFormatToJSONSchema(fields map[string]OutputField) map[string]any {
+	schema := objectJSONSchema(fields)
+	schema["$schema"] = jsonSchemaDialect
+	return schema
+}
+
+// objectJSONSchema describes an object in which every field is required, as
+// every field is present in the output of a run.
+func objectJSONSchema(fields map[string]OutputField) map[string]any {
+	properties := make(map[string]any, len(fields))
+	required := make([]string, 0, len(fields))
+	for name, field := range fields {
+		properties[name] = field.jsonSchema()
+		required = append(required, name)
+	}
+	slices.Sort(required)
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func (f OutputField) jsonSchema() map[string]any {
+	schema := typeJSONSchema(f.Type, f.NestedStructure)
+	if f.Type == "list" && f.ItemType != "" {
+		schema["items"] = typeJSONSchema(f.ItemType, f.NestedStructure)
+	}
+	if f.Description != "" {
+		schema["description"] = f.Description
+	}
+	if f.Options != nil {
+		schema["enum"] = f.Options
+	}
+	return schema
+}
+
+func typeJSONSchema(typ string, nested map[string]OutputField) map[string]any {
+	if typ == "object" && nested != nil {
+		return objectJSONSchema(nested)
+	}
+	for jsonType, fieldType := range jsonSchemaTypes {
+		if fieldType == typ {
+			return map[string]any{"type": jsonType}
+		}
+	}
+	return map[string]any{}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormatJSONSchema(t *testing.T) {
+
+	schema := []byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Joke",
+		"type": "object",
+		"properties": {
+			"joke": {"type": "string", "description": "The joke itself"},
+			"rating": {"type": "integer"},
+			"score": {"type": "number"},
+			"funny": {"type": "boolean"},
+			"sentiment": {"type": "string", "enum": ["positive", "negative"]},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"authors": {
+				"type": "array",
+				"items": {"type": "object", "properties": {"name": {"type": "string"}}, "required": ["name"]}
+			},
+			"meta": {
+				"type": "object",
+				"properties": {"source": {"type": "string"}},
+				"required": ["source"],
+				"additionalProperties": false
+			}
+		},
+		"required": ["joke", "rating", "score", "funny", "sentiment", "tags", "authors", "meta"]
+	}`)
+
+	t.Run("test that it converts a json schema into an output format", func(t *testing.T) {
+		fields, err := sdk.OutputFormatFromJSONSchema(schema)
+		assert.NoError(t, err)
+
+		format, err := sdk.FormatOutputFormat(fields)
+		assert.NoError(t, err)
+		assert.Equal(t, "int", format["rating"])
+		assert.Equal(t, "float", format["score"])
+		assert.Equal(t, "bool", format["funny"])
+		assert.JSONEq(t, `{"type": "str", "description": "The joke itself"}`, format["joke"])
+		assert.JSONEq(t, `{"type": "str", "options": ["positive", "negative"]}`, format["sentiment"])
+		assert.JSONEq(t, `{"type": "list", "item_type": "str"}`, format["tags"])
+		assert.JSONEq(t, `{"type": "list", "item_type": "object", "nested_structure": {"name": "str"}}`, format["authors"])
+		assert.JSONEq(t, `{"type": "object", "nested_structure": {"source": "str"}}`, format["meta"])
+
+		parsed, err := sdk.ParseOutputFormat(format)
+		assert.NoError(t, err)
+		assert.Equal(t, fields, parsed)
+	})
+
+	t.Run("test that an output format converts back into an equivalent json schema", func(t *testing.T) {
+		fields, err := sdk.OutputFormatFromJSONSchema(schema)
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(sdk.OutputFormatToJSONSchema(fields))
+		assert.NoError(t, err)
+
+		again, err := sdk.OutputFormatFromJSONSchema(data)
+		assert.NoError(t, err)
+		assert.Equal(t, fields, again)
+	})
+
+	t.Run("test that it describes every field as required", func(t *testing.T) {
+		format, err := sdk.ParseOutputFormat(map[string]string{
+			"joke":   "str",
+			"rating": `{"type": "int", "description": "From 1 to 10"}`,
+		})
+		assert.NoError(t, err)
+
+		data, err := json.Marshal(sdk.OutputFormatToJSONSchema(format))
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"joke": {"type": "string"},
+				"rating": {"type": "integer", "description": "From 1 to 10"}
+			},
+			"required": ["joke", "rating"],
+			"additionalProperties": false
+		}`, string(data))
+	})
+
+	t.Run("test that unsupported constructs are rejected", func(t *testing.T) {
+		for schema, expected := range map[string]string{
+			`{"type": "array", "items": {"type": "string"}}`:                                                                          "schema: must describe an object with at least one property",
+			`{"type": "object", "properties": {"a": {"$ref": "#/$defs/a"}}}`:                                                          `a: unsupported keyword "$ref"`,
+			`{"type": "object", "properties": {"a": {"oneOf": [{"type": "string"}]}}}`:                                                `a: unsupported keyword "oneOf"`,
+			`{"type": "object", "properties": {"a": {"type": ["string", "null"]}}}`:                                                   "a: type must be a single type name",
+			`{"type": "object", "properties": {"a": {"type": "null"}}}`:                                                               `a: unsupported type "null"`,
+			`{"type": "object", "properties": {"a": {"type": "integer", "enum": [1, 2]}}}`:                                            "a: enum is only supported as a list of strings",
+			`{"type": "object", "properties": {"a": {"type": "array", "items": {"type": "array"}}}}`:                                  "a: lists of lists are not supported",
+			`{"type": "object", "properties": {"a": {"type": "object", "additionalProperties": true}}}`:                               "a: additionalProperties is only supported when false",
+			`{"type": "object", "properties": {"a": {"type": "object", "properties": {"b": {"not": {}}}}}}`:                           `a.b: unsupported keyword "not"`,
+			`{"type": "object", "properties": {"a": {"type": "string"}, "b": {"type": "string"}}}`:                                    "schema: required must list every property, missing a, b",
+			`{"type": "object", "properties": {"a": {"type": "string"}}, "required": "a"}`:                                            "schema: required must be a list of property names",
+			`{"type": "object", "properties": {"a": {"type": "object", "properties": {"b": {"type": "string"}}}}, "required": ["a"]}`: "a: required must list every property, missing b",
+		} {
+			_, err := sdk.OutputFormatFromJSONSchema([]byte(schema))
+			assert.EqualError(t, err, expected, schema)
+		}
+	})
+}
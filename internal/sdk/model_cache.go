@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+
+	"github.com/benbjohnson/clock"
+)
+
+// modelCache holds the model catalog for a TTL and makes sure that concurrent
+// callers share a single in-flight request rather than each making their own.
+type modelCache struct {
+	lock      sync.Mutex
+	clock     clock.Clock
+	ttl       time.Duration
+	models    []entitites.Model
+	expiresAt time.Time
+	call      *modelCacheCall
+}
+
+type modelCacheCall struct {
+	done   chan struct{}
+	models []entitites.Model
+	err    error
+}
+
+func newModelCache(clock clock.Clock, ttl time.Duration) *modelCache {
+	return &modelCache{
+		lock:  sync.Mutex{},
+		clock: clock,
+		ttl:   ttl,
+	}
+}
+
+func (mc *modelCache) get(ctx context.Context, fetch func(context.Context) ([]entitites.Model, error)) ([]entitites.Model, error) {
+	if mc.ttl <= 0 {
+		return fetch(ctx)
+	}
+
+	mc.lock.Lock()
+
+	if mc.models != nil && mc.clock.Now().Before(mc.expiresAt) {
+		models := slices.Clone(mc.models)
+		mc.lock.Unlock()
+		return models, nil
+	}
+
+	call := mc.call
+	if call == nil {
+		call = &modelCacheCall{done: make(chan struct{})}
+		mc.call = call
+		// the request is shared by every caller that arrives while it is in
+		// flight, so it must outlive the context of the caller that made it.
+		go mc.fetch(context.WithoutCancel(ctx), fetch, call)
+	}
+	mc.lock.Unlock()
+
+	select {
+	case <-call.done:
+		return slices.Clone(call.models), call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (mc *modelCache) fetch(ctx context.Context, fetch func(context.Context) ([]entitites.Model, error), call *modelCacheCall) {
+	call.models, call.err = fetch(ctx)
+
+	mc.lock.Lock()
+	if call.err == nil {
+		mc.models = call.models
+		mc.expiresAt = mc.clock.Now().Add(mc.ttl)
+	}
+	mc.call = nil
+	mc.lock.Unlock()
+
+	close(call.done)
+}
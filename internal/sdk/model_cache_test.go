@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModelCache(t *testing.T) {
+
+	ctx := context.Background()
+
+	mockOAuthTokenResponse := []byte(`{
+		"access_token": "dummy-access-token",
+		"expires_in": 3599
+	}`)
+
+	mockModelsResponse := []byte(`[
+		{"id": "019010a2-8327-2607-11d7-41bb0a8936d3", "name": "gpt-4o-mini", "provider": "openai"}
+	]`)
+
+	// newClient returns a client whose model requests are answered once
+	// release is closed, or straight away when it is nil.
+	newClient := func(t *testing.T, mockClock clock.Clock, ttl time.Duration, calls *atomic.Int32, release chan struct{}) *sdk.TasksClient {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(mockOAuthTokenResponse)
+		}))
+		t.Cleanup(mockOAuthServer.Close)
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			if release != nil {
+				<-release
+			}
+			_, _ = w.Write(mockModelsResponse)
+		}))
+		t.Cleanup(mockAPIServer.Close)
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, mockClock, http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		return sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+			ModelCacheTTL:       ttl,
+			Clock:               mockClock,
+		})
+	}
+
+	t.Run("test that parallel reads make a single upstream call", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		tc := newClient(t, clock.NewMock(), time.Minute, &calls, release)
+
+		var started, done sync.WaitGroup
+		for i := 0; i < 15; i++ {
+			started.Add(1)
+			done.Add(1)
+			go func() {
+				defer done.Done()
+				started.Done()
+				models, err := tc.GetAvailableLLMModels(ctx)
+				assert.NoError(t, err)
+				assert.Len(t, models, 1)
+			}()
+		}
+		started.Wait()
+		close(release)
+		done.Wait()
+
+		_, err := tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("test that the catalog is requested again once the ttl expires", func(t *testing.T) {
+		var calls atomic.Int32
+		mockClock := clock.NewMock()
+		tc := newClient(t, mockClock, time.Minute, &calls, nil)
+
+		_, err := tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		mockClock.Add(59 * time.Second)
+		_, err = tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(1), calls.Load())
+
+		mockClock.Add(time.Second)
+		_, err = tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("test that cancelling the first caller does not fail the others", func(t *testing.T) {
+		var calls atomic.Int32
+		release := make(chan struct{})
+		tc := newClient(t, clock.NewMock(), time.Minute, &calls, release)
+
+		leaderCtx, cancel := context.WithCancel(ctx)
+		leader := make(chan error)
+		go func() {
+			_, err := tc.GetAvailableLLMModels(leaderCtx)
+			leader <- err
+		}()
+		assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+		follower := make(chan error)
+		go func() {
+			models, err := tc.GetAvailableLLMModels(ctx)
+			assert.Len(t, models, 1)
+			follower <- err
+		}()
+
+		cancel()
+		assert.ErrorIs(t, <-leader, context.Canceled)
+
+		close(release)
+		assert.NoError(t, <-follower)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("test that the cache can be disabled or bypassed", func(t *testing.T) {
+		var calls atomic.Int32
+		tc := newClient(t, clock.NewMock(), 0, &calls, nil)
+
+		_, err := tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		_, err = tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+
+		calls.Store(0)
+		tc = newClient(t, clock.NewMock(), time.Minute, &calls, nil)
+
+		_, err = tc.GetAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		_, err = tc.FetchAvailableLLMModels(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
@@ -0,0 +1,257 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// OutputField describes a single field of a task's output format. In the
+// Terraform representation a field is either a bare type name, such as "str",
+// or the JSON encoding of an OutputField.
+type OutputField struct {
+	Type            string                 `json:"type"`
+	Description     string                 `json:"description,omitempty"`
+	Options         []string               `json:"options,omitempty"`
+	ItemType        string                 `json:"item_type,omitempty"`
+	NestedStructure map[string]OutputField `json:"nested_structure,omitempty"`
+}
+
+// UnmarshalJSON accepts either a bare type name or an object, as nested
+// fields may use either form.
+func (f *OutputField) UnmarshalJSON(data []byte) error {
+	var typ string
+	if err := json.Unmarshal(data, &typ); err == nil {
+		*f = OutputField{Type: typ}
+		return nil
+	}
+	type outputField OutputField
+	return json.Unmarshal(data, (*outputField)(f))
+}
+
+// MarshalJSON writes fields that only have a type as a bare type name.
+func (f OutputField) MarshalJSON() ([]byte, error) {
+	if f.Description == "" && f.Options == nil && f.ItemType == "" && f.NestedStructure == nil {
+		return json.Marshal(f.Type)
+	}
+	type outputField OutputField
+	return json.Marshal(outputField(f))
+}
+
+// OutputFieldTypes are the type names that an output field may have.
+var OutputFieldTypes = []string{"str", "int", "float", "bool", "list", "object"}
+
+// ParseOutputFormat parses the Terraform representation of an output format.
+func ParseOutputFormat(format map[string]string) (map[string]OutputField, error) {
+	fields := make(map[string]OutputField, len(format))
+	for name, value := range format {
+		var field OutputField
+		if strings.HasPrefix(strings.TrimSpace(value), "{") {
+			if err := json.Unmarshal([]byte(value), &field); err != nil {
+				return nil, fmt.Errorf("output field %q: %w", name, err)
+			}
+		} else {
+			field.Type = value
+		}
+		if err := field.check(); err != nil {
+			return nil, fmt.Errorf("output field %q: %w", name, err)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// FormatOutputFormat returns the Terraform representation of an output
+// format, the inverse of ParseOutputFormat.
+func FormatOutputFormat(fields map[string]OutputField) (map[string]string, error) {
+	format := make(map[string]string, len(fields))
+	for name, field := range fields {
+		if err := field.check(); err != nil {
+			return nil, fmt.Errorf("output field %q: %w", name, err)
+		}
+		data, err := json.Marshal(field)
+		if err != nil {
+			return nil, err
+		}
+		var typ string
+		if json.Unmarshal(data, &typ) == nil {
+			format[name] = typ
+		} else {
+			format[name] = string(data)
+		}
+	}
+	return format, nil
+}
+
+func (f OutputField) check() error {
+	if !slices.Contains(OutputFieldTypes, f.Type) {
+		return fmt.Errorf("unknown type %q, expected one of %s", f.Type, strings.Join(OutputFieldTypes, ", "))
+	}
+	if len(f.Options) > 0 && f.Type != "str" {
+		return fmt.Errorf("options are only supported by str fields")
+	}
+	if f.ItemType != "" && f.Type != "list" {
+		return fmt.Errorf("item_type is only supported by list fields")
+	}
+	if f.ItemType != "" && !slices.Contains(OutputFieldTypes, f.ItemType) {
+		return fmt.Errorf("unknown item_type %q, expected one of %s", f.ItemType, strings.Join(OutputFieldTypes, ", "))
+	}
+	if f.NestedStructure != nil && f.Type != "object" && f.ItemType != "object" {
+		return fmt.Errorf("nested_structure is only supported by object fields and lists of objects")
+	}
+	for name, nested := range f.NestedStructure {
+		if err := nested.check(); err != nil {
+			return fmt.Errorf("nested field %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// DecodeOutput decodes a JSON document, keeping numbers exact so that
+// integers can be told apart from floats.
+func DecodeOutput(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return v, nil
+}
+
+// ValidateOutput returns a violation for every part of output, as decoded
+// by DecodeOutput, that does not conform to format. Violations are sorted
+// and prefixed with the path of the offending value.
+func ValidateOutput(format map[string]OutputField, output any) []string {
+	var violations []string
+	validateObject(format, "", output, &violations)
+	slices.Sort(violations)
+	return violations
+}
+
+func validateObject(fields map[string]OutputField, path string, v any, violations *[]string) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		*violations = append(*violations, fmt.Sprintf("%s: expected object, got %s", displayPath(path), jsonTypeName(v)))
+		return
+	}
+	for name, field := range fields {
+		value, ok := obj[name]
+		if !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: missing", joinPath(path, name)))
+			continue
+		}
+		field.validate(joinPath(path, name), value, violations)
+	}
+	for name := range obj {
+		if _, ok := fields[name]; !ok {
+			*violations = append(*violations, fmt.Sprintf("%s: unexpected field", joinPath(path, name)))
+		}
+	}
+}
+
+// Validate returns a violation for every part of v, as decoded by
+// DecodeOutput, that does not conform to the field.
+func (f OutputField) Validate(path string, v any) []string {
+	var violations []string
+	f.validate(displayPath(path), v, &violations)
+	slices.Sort(violations)
+	return violations
+}
+
+func (f OutputField) validate(path string, v any, violations *[]string) {
+	if !matchesType(f.Type, v) {
+		*violations = append(*violations, fmt.Sprintf("%s: expected %s, got %s", path, f.Type, jsonTypeName(v)))
+		return
+	}
+	switch f.Type {
+	case "str":
+		if len(f.Options) > 0 && !slices.Contains(f.Options, v.(string)) { //nolint:forcetypeassert
+			*violations = append(*violations, fmt.Sprintf("%s: %q is not one of %s", path, v, strings.Join(f.Options, ", ")))
+		}
+	case "list":
+		if f.ItemType == "" {
+			return
+		}
+		item := OutputField{Type: f.ItemType, NestedStructure: f.NestedStructure}
+		for i, value := range v.([]any) { //nolint:forcetypeassert
+			item.validate(fmt.Sprintf("%s[%d]", path, i), value, violations)
+		}
+	case "object":
+		if f.NestedStructure != nil {
+			validateObject(f.NestedStructure, path, v, violations)
+		}
+	}
+}
+
+func matchesType(typ string, v any) bool {
+	switch typ {
+	case "str":
+		_, ok := v.(string)
+		return ok
+	case "bool":
+		_, ok := v.(bool)
+		return ok
+	case "list":
+		_, ok := v.([]any)
+		return ok
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "float":
+		_, ok := v.(json.Number)
+		return ok
+	case "int":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return false
+}
+
+func jsonTypeName(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "str"
+	case bool:
+		return "bool"
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	case json.Number:
+		if matchesType("int", v) {
+			return "int"
+		}
+		return "float"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "output"
+	}
+	return path
+}
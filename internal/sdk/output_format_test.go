@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk_test
+
+import (
+	"testing"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormat(t *testing.T) {
+
+	format, err := sdk.ParseOutputFormat(map[string]string{
+		"joke":      "str",
+		"rating":    `{"type": "int", "description": "From 1 to 10"}`,
+		"sentiment": `{"type": "str", "options": ["positive", "negative"]}`,
+		"tags":      `{"type": "list", "item_type": "str"}`,
+		"author":    `{"type": "object", "nested_structure": {"name": "str", "age": "int"}}`,
+		"score":     "float",
+	})
+	assert.NoError(t, err)
+
+	t.Run("test that it parses type names and objects", func(t *testing.T) {
+		assert.Equal(t, sdk.OutputField{Type: "str"}, format["joke"])
+		assert.Equal(t, sdk.OutputField{Type: "int", Description: "From 1 to 10"}, format["rating"])
+		assert.Equal(t, sdk.OutputField{Type: "str"}, format["author"].NestedStructure["name"])
+	})
+
+	t.Run("test that unsupported constructs are rejected", func(t *testing.T) {
+		_, err := sdk.ParseOutputFormat(map[string]string{"joke": "string"})
+		assert.EqualError(t, err, `output field "joke": unknown type "string", expected one of str, int, float, bool, list, object`)
+
+		_, err = sdk.ParseOutputFormat(map[string]string{"rating": `{"type": "int", "options": ["1"]}`})
+		assert.EqualError(t, err, `output field "rating": options are only supported by str fields`)
+
+		_, err = sdk.ParseOutputFormat(map[string]string{"author": `{"type": "object", "nested_structure": {"age": "integer"}}`})
+		assert.EqualError(t, err, `output field "author": nested field "age": unknown type "integer", expected one of str, int, float, bool, list, object`)
+	})
+
+	t.Run("test that a conforming output has no violations", func(t *testing.T) {
+		output, err := sdk.DecodeOutput([]byte(`{
+			"joke": "Why did the chicken cross the road?",
+			"rating": 7,
+			"sentiment": "positive",
+			"tags": ["chickens", "roads"],
+			"author": {"name": "Anon", "age": 30},
+			"score": 0.5
+		}`))
+		assert.NoError(t, err)
+		assert.Empty(t, sdk.ValidateOutput(format, output))
+	})
+
+	t.Run("test that it reports every violation", func(t *testing.T) {
+		output, err := sdk.DecodeOutput([]byte(`{
+			"rating": 7.5,
+			"sentiment": "neutral",
+			"tags": ["chickens", 2],
+			"author": {"name": "Anon", "age": "30", "email": "anon@example.com"},
+			"score": 1,
+			"extra": null
+		}`))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"author.age: expected int, got str",
+			"author.email: unexpected field",
+			"extra: unexpected field",
+			"joke: missing",
+			"rating: expected int, got float",
+			`sentiment: "neutral" is not one of positive, negative`,
+			"tags[1]: expected str, got int",
+		}, sdk.ValidateOutput(format, output))
+	})
+
+	t.Run("test that the output must be an object", func(t *testing.T) {
+		output, err := sdk.DecodeOutput([]byte(`["joke"]`))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"output: expected object, got list"}, sdk.ValidateOutput(format, output))
+	})
+}
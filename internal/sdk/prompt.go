@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var promptParamNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// PromptParam is a single {placeholder} found in a prompt template.
+type PromptParam struct {
+	Name   string
+	Offset int
+}
+
+// PromptSyntaxError describes a malformed prompt template.
+type PromptSyntaxError struct {
+	Offset int
+	Reason string
+}
+
+func (e *PromptSyntaxError) Error() string {
+	return fmt.Sprintf("invalid prompt template at offset %d: %s", e.Offset, e.Reason)
+}
+
+// ParsePromptParams returns every {placeholder} in prompt in the order in
+// which they appear, including repeats. Braces are escaped by doubling them,
+// so "{{" and "}}" render as a literal "{" and "}".
+func ParsePromptParams(prompt string) ([]PromptParam, error) {
+	var params []PromptParam
+	for i := 0; i < len(prompt); i++ {
+		switch prompt[i] {
+		case '{':
+			if i+1 < len(prompt) && prompt[i+1] == '{' {
+				i++
+				continue
+			}
+			end := i + 1
+			for end < len(prompt) && prompt[end] != '}' && prompt[end] != '{' {
+				end++
+			}
+			if end == len(prompt) || prompt[end] == '{' {
+				return nil, &PromptSyntaxError{Offset: i, Reason: "unclosed '{'"}
+			}
+			name := prompt[i+1 : end]
+			if !promptParamNamePattern.MatchString(name) {
+				return nil, &PromptSyntaxError{Offset: i, Reason: fmt.Sprintf("invalid parameter name %q", name)}
+			}
+			params = append(params, PromptParam{Name: name, Offset: i})
+			i = end
+		case '}':
+			if i+1 < len(prompt) && prompt[i+1] == '}' {
+				i++
+				continue
+			}
+			return nil, &PromptSyntaxError{Offset: i, Reason: "single '}' encountered"}
+		}
+	}
+	return params, nil
+}
+
+// PromptParamNames returns the distinct {placeholder} names in prompt in the
+// order in which they first appear.
+func PromptParamNames(prompt string) ([]string, error) {
+	params, err := ParsePromptParams(prompt)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(params))
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		names = append(names, p.Name)
+	}
+	return names, nil
+}
+
+// RenderPrompt substitutes the value of each {placeholder} in prompt and
+// turns doubled braces back into literals, as the API does when a task is
+// run. Values that are not referenced by the prompt are ignored.
+func RenderPrompt(prompt string, values map[string]string) (string, error) {
+	params, err := ParsePromptParams(prompt)
+	if err != nil {
+		return "", err
+	}
+
+	var missing []string
+	for _, p := range params {
+		if _, ok := values[p.Name]; !ok && !slices.Contains(missing, p.Name) {
+			missing = append(missing, p.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("missing values for prompt parameters: %s", strings.Join(missing, ", "))
+	}
+
+	var sb strings.Builder
+	start := 0
+	for _, p := range params {
+		sb.WriteString(unescapePromptBraces(prompt[start:p.Offset]))
+		sb.WriteString(values[p.Name])
+		start = p.Offset + len(p.Name) + 2
+	}
+	sb.WriteString(unescapePromptBraces(prompt[start:]))
+
+	return sb.String(), nil
+}
+
+func unescapePromptBraces(s string) string {
+	return strings.NewReplacer("{{", "{", "}}", "}").Replace(s)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk_test
+
+import (
+	"testing"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromptParamNames(t *testing.T) {
+
+	t.Run("test that it returns distinct params in order", func(t *testing.T) {
+		names, err := sdk.PromptParamNames("Compare {a} with {b_2} and then {a} again")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b_2"}, names)
+	})
+
+	t.Run("test that doubled braces are literals", func(t *testing.T) {
+		names, err := sdk.PromptParamNames(`Respond with {{"answer": "{subject}"}}`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"subject"}, names)
+	})
+
+	t.Run("test that a prompt without params returns an empty list", func(t *testing.T) {
+		names, err := sdk.PromptParamNames("Tell me a joke")
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+	})
+
+	t.Run("test that malformed braces are rejected", func(t *testing.T) {
+		for _, prompt := range []string{"{subject", "subject}", "{}", "{not valid}", "{a{b}"} {
+			_, err := sdk.PromptParamNames(prompt)
+			var syntaxErr *sdk.PromptSyntaxError
+			assert.ErrorAs(t, err, &syntaxErr, prompt)
+		}
+	})
+}
+
+func TestRenderPrompt(t *testing.T) {
+
+	t.Run("test that it substitutes every placeholder", func(t *testing.T) {
+		prompt, err := sdk.RenderPrompt("Compare {a} with {b} and then {a} again", map[string]string{"a": "apples", "b": "pears", "c": "unused"})
+		assert.NoError(t, err)
+		assert.Equal(t, "Compare apples with pears and then apples again", prompt)
+	})
+
+	t.Run("test that doubled braces render as literals", func(t *testing.T) {
+		prompt, err := sdk.RenderPrompt(`Respond with {{"answer": "{subject}"}}`, map[string]string{"subject": "{chickens}"})
+		assert.NoError(t, err)
+		assert.Equal(t, `Respond with {"answer": "{chickens}"}`, prompt)
+	})
+
+	t.Run("test that missing values are an error", func(t *testing.T) {
+		_, err := sdk.RenderPrompt("Compare {a} with {b} and {c} and then {b} again", map[string]string{"a": "apples"})
+		assert.EqualError(t, err, "missing values for prompt parameters: b, c")
+	})
+
+	t.Run("test that malformed prompts are an error", func(t *testing.T) {
+		_, err := sdk.RenderPrompt("Tell me about {subject", map[string]string{"subject": "chickens"})
+		var syntaxErr *sdk.PromptSyntaxError
+		assert.ErrorAs(t, err, &syntaxErr)
+	})
+}
@@ -10,20 +10,24 @@ import (
 type CreateTaskRequest struct {
 	Description     string                      `json:"description"`
 	Enabled         bool                        `json:"enabled"`
+	ExposedToAgents bool                        `json:"exposed_to_agents"`
 	ImageRequired   bool                        `json:"image_required"`
 	InputProcessors *[]entitites.InputProcessor `json:"input_processors"`
 	LLMModelID      string                      `json:"llm_model_id"`
 	Name            string                      `json:"name"`
-	OutputFormat    map[string]string           `json:"output_format"`
+	OptimiseImages  bool                        `json:"optimise_images"`
+	OutputFormat    entitites.OutputFormat      `json:"output_format"`
 	OutputModality  string                      `json:"output_modality"`
 	Public          bool                        `json:"public"`
+	RAG             *entitites.RAG              `json:"rag"`
 	SystemPrompt    string                      `json:"system_prompt"`
+	TaskForwarderID *string                     `json:"task_forwarder_id"`
 	UserPrompt      string                      `json:"user_prompt"`
 }
 
 func NewCreateTaskRequest() CreateTaskRequest {
 	return CreateTaskRequest{
-		OutputFormat: make(map[string]string, 0),
+		OutputFormat: make(entitites.OutputFormat, 0),
 	}
 }
 
@@ -31,21 +35,39 @@ type UpdateTaskRequest struct {
 	ID              string                      `json:"id"`
 	Description     string                      `json:"description"`
 	Enabled         bool                        `json:"enabled"`
+	ExposedToAgents bool                        `json:"exposed_to_agents"`
 	ImageRequired   bool                        `json:"image_required"`
 	InputProcessors *[]entitites.InputProcessor `json:"input_processors"`
 	LLMModelID      string                      `json:"llm_model_id"`
 	Name            string                      `json:"name"`
-	OutputFormat    map[string]string           `json:"output_format"`
+	OptimiseImages  bool                        `json:"optimise_images"`
+	OutputFormat    entitites.OutputFormat      `json:"output_format"`
 	OutputModality  string                      `json:"output_modality"`
 	Public          bool                        `json:"public"`
+	RAG             *entitites.RAG              `json:"rag"`
 	SystemPrompt    string                      `json:"system_prompt"`
+	TaskForwarderID *string                     `json:"task_forwarder_id"`
 	UserPrompt      string                      `json:"user_prompt"`
 }
 
 func NewUpdateTaskRequest(id string) UpdateTaskRequest {
 	return UpdateTaskRequest{
 		ID:           id,
-		OutputFormat: make(map[string]string, 0),
+		OutputFormat: make(entitites.OutputFormat, 0),
+	}
+}
+
+type ListTasksRequest struct {
+	Page      int    `json:"page"`
+	PageLimit int    `json:"page_limit"`
+	Name      string `json:"name"`
+	Enabled   *bool  `json:"enabled"`
+}
+
+func NewListTasksRequest() ListTasksRequest {
+	return ListTasksRequest{
+		Page:      1,
+		PageLimit: DefaultPageLimit,
 	}
 }
 
@@ -68,3 +90,70 @@ func NewFetchTaskRequest(id string) FetchTaskRequest {
 		ID: id,
 	}
 }
+
+type CreateTaskForwarderRequest struct {
+	AuthSecret     *string           `json:"auth_secret,omitempty"`
+	DestinationURL string            `json:"destination_url"`
+	Headers        map[string]string `json:"headers"`
+	Name           string            `json:"name"`
+}
+
+func NewCreateTaskForwarderRequest() CreateTaskForwarderRequest {
+	return CreateTaskForwarderRequest{
+		Headers: make(map[string]string, 0),
+	}
+}
+
+type UpdateTaskForwarderRequest struct {
+	ID             string            `json:"id"`
+	AuthSecret     *string           `json:"auth_secret,omitempty"`
+	DestinationURL string            `json:"destination_url"`
+	Headers        map[string]string `json:"headers"`
+	Name           string            `json:"name"`
+}
+
+func NewUpdateTaskForwarderRequest(id string) UpdateTaskForwarderRequest {
+	return UpdateTaskForwarderRequest{
+		ID:      id,
+		Headers: make(map[string]string, 0),
+	}
+}
+
+type FetchTaskForwarderRequest struct {
+	ID string `json:"id"`
+}
+
+func NewFetchTaskForwarderRequest(id string) FetchTaskForwarderRequest {
+	return FetchTaskForwarderRequest{
+		ID: id,
+	}
+}
+
+type DeleteTaskForwarderRequest struct {
+	ID string `json:"id"`
+}
+
+func NewDeleteTaskForwarderRequest(id string) DeleteTaskForwarderRequest {
+	return DeleteTaskForwarderRequest{
+		ID: id,
+	}
+}
+
+type RunTaskRequest struct {
+	TaskID string            `json:"-"`
+	Input  map[string]string `json:"task_input"`
+	Files  []RunTaskFile     `json:"-"`
+}
+
+// RunTaskFile is a file, such as an image, uploaded with a Task run.
+type RunTaskFile struct {
+	Name string
+	Data []byte
+}
+
+func NewRunTaskRequest(taskID string) RunTaskRequest {
+	return RunTaskRequest{
+		TaskID: taskID,
+		Input:  make(map[string]string, 0),
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+)
+
+func (tc *TasksClient) FetchTaskForwarder(ctx context.Context, in FetchTaskForwarderRequest) (*entitites.TaskForwarder, error) {
+	url := fmt.Sprintf("%s/task_forwarder/%s", tc.getBaseAPIURL(), in.ID)
+	tc.log.Info("fetching task forwarder", "id", in.ID, "url", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	res, err := tc.DoWithAuth(ctx, req)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	if err := tc.assertStatusCode("cannot fetch task forwarder", http.StatusOK, res); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	forwarder := new(entitites.TaskForwarder)
+	if err := json.NewDecoder(res.Body).Decode(&forwarder); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	return forwarder, nil
+}
+
+func (tc *TasksClient) CreateTaskForwarder(ctx context.Context, in CreateTaskForwarderRequest) (*entitites.TaskForwarder, error) {
+	var data = new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(&in); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/task_forwarder", tc.getBaseAPIURL())
+	tc.log.Info("creating task forwarder", "url", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, data)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := tc.DoWithAuth(ctx, req)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	if err := tc.assertStatusCode("cannot create task forwarder", http.StatusOK, res); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	forwarder := new(entitites.TaskForwarder)
+	if err := json.NewDecoder(res.Body).Decode(&forwarder); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	return forwarder, nil
+}
+
+func (tc *TasksClient) UpdateTaskForwarder(ctx context.Context, in UpdateTaskForwarderRequest) (*entitites.TaskForwarder, error) {
+	var data = new(bytes.Buffer)
+	if err := json.NewEncoder(data).Encode(&in); err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/task_forwarder/%s", tc.getBaseAPIURL(), in.ID)
+	tc.log.Info("updating task forwarder", "id", in.ID, "url", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, data)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	res, err := tc.DoWithAuth(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.assertStatusCode("cannot update task forwarder", http.StatusOK, res); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	forwarder := new(entitites.TaskForwarder)
+	if err := json.NewDecoder(res.Body).Decode(&forwarder); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	return forwarder, nil
+}
+
+func (tc *TasksClient) DeleteTaskForwarder(ctx context.Context, in DeleteTaskForwarderRequest) error {
+	url := fmt.Sprintf("%s/task_forwarder/%s", tc.getBaseAPIURL(), in.ID)
+	tc.log.Info("deleting task forwarder", "id", in.ID, "url", url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := tc.DoWithAuth(ctx, req)
+	if err != nil {
+		return err
+	}
+	if err := tc.assertStatusCode("cannot delete task forwarder", http.StatusOK, res); err != nil {
+		tc.log.Error(err.Error())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskForwarders(t *testing.T) {
+
+	ctx := context.Background()
+
+	mockOAuthTokenResponse := []byte(`{
+		"access_token": "dummy-access-token",
+		"expires_in": 3599
+	}`)
+
+	mockTaskForwarderResponse := []byte(`{
+		"id": "0191a3b2-0000-0000-0000-000000000001",
+		"name": "webhook",
+		"destination_url": "https://example.com/hook",
+		"headers": {"X-Team": "platform"}
+	}`)
+
+	t.Run("test that it sends a create request with the auth secret", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(mockOAuthTokenResponse)
+		}))
+		defer mockOAuthServer.Close()
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.True(t, strings.HasSuffix(r.RequestURI, "/project/019010a2-8327-2607-11d7-41bb0a8936d4/task_forwarder"))
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "s3cr3t", body["auth_secret"])
+			assert.Equal(t, "https://example.com/hook", body["destination_url"])
+			_, _ = w.Write(mockTaskForwarderResponse)
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		secret := "s3cr3t"
+		in := sdk.NewCreateTaskForwarderRequest()
+		in.Name = "webhook"
+		in.DestinationURL = "https://example.com/hook"
+		in.AuthSecret = &secret
+		forwarder, err := tc.CreateTaskForwarder(ctx, in)
+		assert.NoError(t, err)
+		assert.Equal(t, "0191a3b2-0000-0000-0000-000000000001", forwarder.ID)
+		assert.Equal(t, map[string]string{"X-Team": "platform"}, forwarder.Headers)
+	})
+
+	t.Run("test that it omits the auth secret when not set", func(t *testing.T) {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(mockOAuthTokenResponse)
+		}))
+		defer mockOAuthServer.Close()
+
+		mockAPIServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.True(t, strings.HasSuffix(r.RequestURI, "/task_forwarder/0191a3b2-0000-0000-0000-000000000001"))
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.NotContains(t, body, "auth_secret")
+			_, _ = w.Write(mockTaskForwarderResponse)
+		}))
+		defer mockAPIServer.Close()
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		tc := sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+		_, err = tc.UpdateTaskForwarder(ctx, sdk.NewUpdateTaskForwarderRequest("0191a3b2-0000-0000-0000-000000000001"))
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	entitites "terraform-provider-tasks/internal/sdk/entities"
+)
+
+// Run runs the active revision of a task. The input is sent as JSON, or as a
+// multipart form when files are uploaded with it.
+func (tc *TasksClient) Run(ctx context.Context, in RunTaskRequest) (*entitites.TaskRun, error) {
+	var data = new(bytes.Buffer)
+	contentType := "application/json"
+
+	if len(in.Files) == 0 {
+		if err := json.NewEncoder(data).Encode(&in); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		contentType, err = writeRunTaskForm(data, in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	url := fmt.Sprintf("%s/task/%s/run", tc.getBaseAPIURL(), in.TaskID)
+	tc.log.Info("running task", "id", in.TaskID, "url", url, "files", len(in.Files))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, data)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	res, err := tc.DoWithAuth(ctx, req)
+	if err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	if err := tc.assertStatusCode("cannot run task", http.StatusOK, res); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	run := new(entitites.TaskRun)
+	if err := json.NewDecoder(res.Body).Decode(&run); err != nil {
+		tc.log.Error(err.Error())
+		return nil, err
+	}
+	return run, nil
+}
+
+// writeRunTaskForm writes the input as a `task_input` JSON field followed by
+// a `task_input_files` part for each file, and returns the content type.
+func writeRunTaskForm(w io.Writer, in RunTaskRequest) (string, error) {
+	mw := multipart.NewWriter(w)
+
+	input, err := json.Marshal(in.Input)
+	if err != nil {
+		return "", err
+	}
+	if err := mw.WriteField("task_input", string(input)); err != nil {
+		return "", err
+	}
+
+	for _, file := range in.Files {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "task_input_files", "filename": file.Name}))
+		header.Set("Content-Type", http.DetectContentType(file.Data))
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return "", err
+		}
+		if _, err := part.Write(file.Data); err != nil {
+			return "", err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return "", err
+	}
+
+	return mw.FormDataContentType(), nil
+}
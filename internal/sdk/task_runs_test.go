@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"terraform-provider-tasks/internal/sdk"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskRuns(t *testing.T) {
+
+	ctx := context.Background()
+
+	mockOAuthTokenResponse := []byte(`{
+		"access_token": "dummy-access-token",
+		"expires_in": 3599
+	}`)
+
+	mockTaskRunResponse := []byte(`{
+		"id": "0191a3b2-0000-0000-0000-000000000009",
+		"task_id": "019011e6-e530-3aca-6cf7-2973387c255d",
+		"task_revision_id": "019011e6-e530-3aca-6cf7-2973387c255e",
+		"response": {"joke": "Why did the chicken cross the road?"},
+		"is_error": false,
+		"input_tokens": 12,
+		"output_tokens": 9
+	}`)
+
+	newClient := func(t *testing.T, handler http.HandlerFunc) *sdk.TasksClient {
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(mockOAuthTokenResponse)
+		}))
+		t.Cleanup(mockOAuthServer.Close)
+
+		mockAPIServer := httptest.NewServer(handler)
+		t.Cleanup(mockAPIServer.Close)
+
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, clock.New(), http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+		return sdk.NewTasksClient(sdk.NullLog{}, http.DefaultClient, ts, sdk.Config{
+			RightbrainAPIHost:   mockAPIServer.URL,
+			RightbrainOrgID:     "00000001-00000000-00000000-00000000",
+			RightbrainProjectID: "019010a2-8327-2607-11d7-41bb0a8936d4",
+		})
+	}
+
+	t.Run("test that it sends the input as json", func(t *testing.T) {
+		tc := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/api/v1/org/00000001-00000000-00000000-00000000/project/019010a2-8327-2607-11d7-41bb0a8936d4/task/019011e6-e530-3aca-6cf7-2973387c255d/run", r.URL.Path)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"task_input": map[string]any{"subject": "chickens"}}, body)
+			_, _ = w.Write(mockTaskRunResponse)
+		})
+
+		in := sdk.NewRunTaskRequest("019011e6-e530-3aca-6cf7-2973387c255d")
+		in.Input["subject"] = "chickens"
+		run, err := tc.Run(ctx, in)
+		assert.NoError(t, err)
+		assert.Equal(t, "019011e6-e530-3aca-6cf7-2973387c255e", run.TaskRevisionID)
+		assert.JSONEq(t, `{"joke": "Why did the chicken cross the road?"}`, string(run.Response))
+		assert.Equal(t, int64(9), run.OutputTokens)
+	})
+
+	t.Run("test that it uploads files as a multipart form", func(t *testing.T) {
+		png := []byte("\x89PNG\r\n\x1a\n0000")
+
+		tc := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.NoError(t, r.ParseMultipartForm(1<<20))
+			assert.JSONEq(t, `{"subject": "chickens"}`, r.FormValue("task_input"))
+			file, header, err := r.FormFile("task_input_files")
+			assert.NoError(t, err)
+			assert.Equal(t, "chicken.png", header.Filename)
+			assert.Equal(t, "image/png", header.Header.Get("Content-Type"))
+			data, err := io.ReadAll(file)
+			assert.NoError(t, err)
+			assert.Equal(t, png, data)
+			_, _ = w.Write(mockTaskRunResponse)
+		})
+
+		in := sdk.NewRunTaskRequest("019011e6-e530-3aca-6cf7-2973387c255d")
+		in.Input["subject"] = "chickens"
+		in.Files = []sdk.RunTaskFile{{Name: "chicken.png", Data: png}}
+		_, err := tc.Run(ctx, in)
+		assert.NoError(t, err)
+	})
+
+	t.Run("test that it reports a failed run", func(t *testing.T) {
+		tc := newClient(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		})
+
+		_, err := tc.Run(ctx, sdk.NewRunTaskRequest("019011e6-e530-3aca-6cf7-2973387c255d"))
+		assert.EqualError(t, err, "cannot run task, expected status code 200 but got 422.")
+	})
+}
@@ -49,6 +49,15 @@ func NewTokenStore(log Log, clock clock.Clock, httpClient HttpClient, tokenServe
 	}, nil
 }
 
+// Token is an access token minted for a caller other than the store itself.
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+	// RefreshAt is when the token should be replaced, ahead of ExpiresAt so
+	// that requests in flight do not fail.
+	RefreshAt time.Time
+}
+
 func (ts *TokenStore) Fetch(ctx context.Context, clientID string, clientSecret string) (string, error) {
 
 	ts.lock.Lock()
@@ -58,12 +67,32 @@ func (ts *TokenStore) Fetch(ctx context.Context, clientID string, clientSecret s
 		return ts.token.value, nil
 	}
 
+	t, err := ts.request(ctx, clientID, clientSecret)
+	if err != nil {
+		return "", err
+	}
+
+	ts.token = &token{
+		value:     t.Value,
+		expiresAt: t.RefreshAt,
+	}
+
+	return ts.token.value, nil
+}
+
+// Mint requests a new token that is neither shared with nor cached by the
+// store.
+func (ts *TokenStore) Mint(ctx context.Context, clientID string, clientSecret string) (*Token, error) {
+	return ts.request(ctx, clientID, clientSecret)
+}
+
+func (ts *TokenStore) request(ctx context.Context, clientID string, clientSecret string) (*Token, error) {
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ts.tokenServerURL.String(), strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.SetBasicAuth(clientID, clientSecret)
@@ -71,11 +100,11 @@ func (ts *TokenStore) Fetch(ctx context.Context, clientID string, clientSecret s
 
 	res, err := ts.httpClient.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if res.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("cannot fetch token, expected %d status code but got %d", http.StatusOK, res.StatusCode)
+		return nil, fmt.Errorf("cannot fetch token, expected %d status code but got %d", http.StatusOK, res.StatusCode)
 	}
 
 	defer res.Body.Close()
@@ -85,15 +114,16 @@ func (ts *TokenStore) Fetch(ctx context.Context, clientID string, clientSecret s
 	}{}
 
 	if err := json.NewDecoder(res.Body).Decode(&tokenResponse); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	ts.token = &token{
-		value:     tokenResponse.AccessToken,
-		expiresAt: ts.clock.Now().Add(ts.getExpiryDurationFromExpiresIn(tokenResponse.ExpiresIn)),
-	}
+	now := ts.clock.Now()
 
-	return ts.token.value, nil
+	return &Token{
+		Value:     tokenResponse.AccessToken,
+		ExpiresAt: now.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+		RefreshAt: now.Add(ts.getExpiryDurationFromExpiresIn(tokenResponse.ExpiresIn)),
+	}, nil
 }
 
 func (ts *TokenStore) getExpiryDurationFromExpiresIn(expiresIn int64) time.Duration {
@@ -72,4 +72,31 @@ func TestTokenStore(t *testing.T) {
 
 		assert.Equal(t, 2, calls)
 	})
+
+	t.Run("test that minted tokens are not cached", func(t *testing.T) {
+		calls := 0
+		mockOAuthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			_, _ = w.Write(mockOAuthTokenResponse)
+		}))
+		defer mockOAuthServer.Close()
+
+		cl := clock.NewMock()
+		ts, err := sdk.NewTokenStore(sdk.NullLog{}, cl, http.DefaultClient, mockOAuthServer.URL)
+		assert.NoError(t, err)
+
+		_, err = ts.Fetch(ctx, "", "")
+		assert.NoError(t, err)
+
+		token, err := ts.Mint(ctx, "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "dummy-access-token", token.Value)
+		assert.Equal(t, cl.Now().Add(3599*time.Second), token.ExpiresAt)
+		assert.Equal(t, cl.Now().Add(3239*time.Second), token.RefreshAt)
+
+		_, err = ts.Mint(ctx, "", "")
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, calls)
+	})
 }